@@ -1,7 +1,8 @@
-package server
+package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"onlylight/k8s-mcp-server/internal/config"
 	"onlylight/k8s-mcp-server/internal/logging"
@@ -20,23 +21,22 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := logging.NewLogger("info", "text")
-
-	// Initialize Kubernetes client
-	k8sClient, err := k8s.NewClient(cfg.K8s.ConfigPath, logger.Logger)
+	logger, err := logging.NewLogger(cfg.Log)
 	if err != nil {
-		logger.Fatalf("Failed to create Kubernetes client: %v", err)
+		log.Fatalf("Failed to set up logging: %v", err)
 	}
+	defer logger.Close()
 
-	// Test Kubernetes connection
+	// Initialize Kubernetes clients
 	ctx := context.Background()
-	if err := k8sClient.HealthCheck(ctx); err != nil {
-		logger.Fatalf("Kubernetes health check failed: %v", err)
+	clusters, err := newClusterRegistry(ctx, cfg, logger)
+	if err != nil {
+		logger.Fatalf("Failed to set up Kubernetes clusters: %v", err)
 	}
-	logger.Info("Kubernetes connection established successfully")
+	logger.Infof("Kubernetes connection established successfully (default cluster %s)", clusters.DefaultName())
 
 	// Create MCP server
-	mcpServer := mcp.NewServer(cfg, k8sClient)
+	mcpServer := mcp.NewServer(cfg, clusters, logger)
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -49,9 +49,7 @@ func main() {
 	// Start MCP server
 	serverErrChan := make(chan error, 1)
 	go func() {
-		if err := mcpServer.Start(ctx); err != nil {
-			serverErrChan <- err
-		}
+		serverErrChan <- mcpServer.Start(ctx)
 	}()
 
 	// Wait for shutdown signal or server error
@@ -59,10 +57,73 @@ func main() {
 	case sig := <-sigChan:
 		logger.Infof("Received signal: %v. Shutting down...", sig)
 		cancel()
+		// Let the transport finish in-flight requests before exiting
+		if err := <-serverErrChan; err != nil {
+			logger.Errorf("MCP server error during shutdown: %v", err)
+		}
 	case err := <-serverErrChan:
-		logger.Errorf("MCP server error: %v", err)
+		if err != nil {
+			logger.Errorf("MCP server error: %v", err)
+		}
 		cancel()
 	}
 
 	logger.Info("Server exited gracefully")
 }
+
+// newClusterRegistry connects to the default cluster and every configured cluster.
+// The default cluster must be reachable; extra clusters that fail are skipped with a warning.
+func newClusterRegistry(ctx context.Context, cfg *config.Config, logger *logging.Logger) (*k8s.ClusterRegistry, error) {
+	clusters := k8s.NewClusterRegistry()
+
+	defaultClient, err := k8s.NewClient("", cfg.K8s.ConfigPath, cfg.K8s.Context, cfg.K8s.Namespaces, logger.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	if err := defaultClient.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("kubernetes health check failed: %w", err)
+	}
+	if err := clusters.Add(defaultClient.ContextName(), defaultClient); err != nil {
+		return nil, err
+	}
+
+	addCluster := func(name, configPath, contextName string, namespaces []string) {
+		if _, err := clusters.Get(name); err == nil {
+			return
+		}
+		client, err := k8s.NewClient(name, configPath, contextName, namespaces, logger.Logger)
+		if err == nil {
+			err = client.HealthCheck(ctx)
+		}
+		if err == nil {
+			err = clusters.Add(name, client)
+		}
+		if err != nil {
+			logger.Warnf("Skipping cluster %s: %v", name, err)
+		}
+	}
+
+	for _, cluster := range cfg.K8s.Clusters {
+		configPath := cluster.ConfigPath
+		if configPath == "" {
+			configPath = cfg.K8s.ConfigPath
+		}
+		namespaces := cluster.Namespaces
+		if namespaces == nil {
+			namespaces = cfg.K8s.Namespaces
+		}
+		addCluster(cluster.Name, configPath, cluster.Context, namespaces)
+	}
+
+	if cfg.K8s.AllContexts {
+		contexts, err := k8s.ListContexts(cfg.K8s.ConfigPath)
+		if err != nil {
+			logger.Warnf("Failed to list kubeconfig contexts: %v", err)
+		}
+		for _, c := range contexts {
+			addCluster(c.Name, cfg.K8s.ConfigPath, c.Name, cfg.K8s.Namespaces)
+		}
+	}
+
+	return clusters, nil
+}
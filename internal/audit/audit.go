@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event kinds
+const (
+	KindMCP        = "mcp"        // a request from an MCP client
+	KindKubernetes = "kubernetes" // a call to the Kubernetes API made on behalf of one
+)
+
+// Outcomes
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+	OutcomeRefused = "refused"
+)
+
+// Event is one line of the audit log
+type Event struct {
+	Time          time.Time `json:"time"`
+	CorrelationID string    `json:"correlationId"`
+	Kind          string    `json:"kind"`
+	User          string    `json:"user,omitempty"`
+	Groups        []string  `json:"groups,omitempty"`
+
+	// MCP requests
+	Method    string                 `json:"method,omitempty"` // e.g. tools/call, resources/read
+	Tool      string                 `json:"tool,omitempty"`
+	URI       string                 `json:"uri,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+
+	// Kubernetes API calls
+	Cluster    string `json:"cluster,omitempty"`
+	Verb       string `json:"verb,omitempty"`
+	Resource   string `json:"resource,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+
+	DurationMs float64 `json:"durationMs"`
+	Outcome    string  `json:"outcome"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Logger writes audit events as JSON lines. A nil Logger discards every event.
+type Logger struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewLogger creates a logger writing to w
+func NewLogger(w io.WriteCloser) *Logger {
+	return &Logger{w: w}
+}
+
+// Record writes a single event
+func (l *Logger) Record(event Event) error {
+	if l == nil {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the underlying writer
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Close()
+}
+
+// Trail ties the events of one MCP request together: the request itself and every
+// Kubernetes call made while serving it share its correlation ID and caller.
+type Trail struct {
+	logger        *Logger
+	CorrelationID string
+	User          string
+	Groups        []string
+}
+
+// Record writes an event stamped with the trail's correlation ID and caller
+func (t *Trail) Record(event Event) error {
+	if t == nil {
+		return nil
+	}
+	event.CorrelationID = t.CorrelationID
+	event.User = t.User
+	event.Groups = t.Groups
+	return t.logger.Record(event)
+}
+
+type trailKey struct{}
+
+// Start begins a trail for a new MCP request and attaches it to ctx. It returns ctx
+// unchanged and a nil trail when l is nil.
+func (l *Logger) Start(ctx context.Context, user string, groups []string) (context.Context, *Trail) {
+	if l == nil {
+		return ctx, nil
+	}
+
+	trail := &Trail{
+		logger:        l,
+		CorrelationID: newCorrelationID(),
+		User:          user,
+		Groups:        groups,
+	}
+	return context.WithValue(ctx, trailKey{}, trail), trail
+}
+
+// FromContext returns the trail of the MCP request ctx belongs to
+func FromContext(ctx context.Context) (*Trail, bool) {
+	trail, ok := ctx.Value(trailKey{}).(*Trail)
+	return trail, ok && trail != nil
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// Milliseconds converts a duration to the fractional milliseconds used in events
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
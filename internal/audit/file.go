@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RotatingFile is an append-only file that is rotated once it grows past maxBytes.
+// Rotated files are renamed path.1, path.2, ... with path.1 being the newest, and
+// only the newest maxBackups are kept.
+type RotatingFile struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens path for appending, creating it and its directory if needed
+func OpenRotatingFile(path string, maxBytes int64, maxBackups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f := &RotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p, rotating first if p would push the file past its size limit.
+// Callers must serialize writes; Logger does.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	if f.maxBytes > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (f *RotatingFile) Close() error {
+	return f.file.Close()
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log for rotation: %w", err)
+	}
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove audit log: %w", err)
+		}
+		return f.open()
+	}
+
+	// Shift path.N-1 to path.N, dropping the oldest, then move the current file to path.1
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", f.path, i)
+		to := fmt.Sprintf("%s.%d", f.path, i+1)
+		if err := os.Rename(from, to); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+
+	return f.open()
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// readBackups returns the contents of path and its backups path.1 ... path.n, with ""
+// for files that don't exist
+func readBackups(t *testing.T, path string, n int) []string {
+	t.Helper()
+
+	contents := make([]string, n+1)
+	for i := range contents {
+		name := path
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", path, i)
+		}
+		data, err := os.ReadFile(name)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		contents[i] = string(data)
+	}
+	return contents
+}
+
+func TestRotatingFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBytes   int64
+		maxBackups int
+		writes     []string
+		// want is the content of the file followed by that of path.1, path.2, ...
+		want []string
+	}{
+		{
+			name:       "below the limit",
+			maxBytes:   10,
+			maxBackups: 2,
+			writes:     []string{"aaaa\n", "bbbb\n"},
+			want:       []string{"aaaa\nbbbb\n", "", ""},
+		},
+		{
+			name:       "rotates before a write that would pass the limit",
+			maxBytes:   10,
+			maxBackups: 2,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			want:       []string{"cccc\n", "aaaa\nbbbb\n", ""},
+		},
+		{
+			name:       "shifts backups",
+			maxBytes:   5,
+			maxBackups: 3,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			want:       []string{"cccc\n", "bbbb\n", "aaaa\n", ""},
+		},
+		{
+			name:       "prunes the oldest backup",
+			maxBytes:   5,
+			maxBackups: 2,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n"},
+			want:       []string{"dddd\n", "cccc\n", "bbbb\n", ""},
+		},
+		{
+			name:       "no backups",
+			maxBytes:   5,
+			maxBackups: 0,
+			writes:     []string{"aaaa\n", "bbbb\n"},
+			want:       []string{"bbbb\n", ""},
+		},
+		{
+			name:       "oversized write into an empty file",
+			maxBytes:   5,
+			maxBackups: 1,
+			writes:     []string{"aaaaaaaaaa\n", "bbbb\n"},
+			want:       []string{"bbbb\n", "aaaaaaaaaa\n"},
+		},
+		{
+			name:       "no limit",
+			maxBytes:   0,
+			maxBackups: 1,
+			writes:     []string{"aaaa\n", "bbbb\n", "cccc\n"},
+			want:       []string{"aaaa\nbbbb\ncccc\n", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+			f, err := OpenRotatingFile(path, tt.maxBytes, tt.maxBackups)
+			if err != nil {
+				t.Fatalf("OpenRotatingFile() error = %v", err)
+			}
+			for _, write := range tt.writes {
+				if _, err := f.Write([]byte(write)); err != nil {
+					t.Fatalf("Write(%q) error = %v", write, err)
+				}
+			}
+			if err := f.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			got := readBackups(t, path, len(tt.want)-1)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("file %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestRotatingFileReopen checks that the size of an existing file counts toward the limit
+func TestRotatingFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, []byte("aaaa\n"), 0o600); err != nil {
+		t.Fatalf("failed to write audit log: %v", err)
+	}
+
+	f, err := OpenRotatingFile(path, 8, 1)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile() error = %v", err)
+	}
+	if _, err := f.Write([]byte("bbbb\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got := readBackups(t, path, 1)
+	if want := []string{"bbbb\n", "aaaa\n"}; !slices.Equal(got, want) {
+		t.Errorf("files = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package audit
+
+import "strings"
+
+// Redacted replaces the value of sensitive arguments in audit events
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are substrings of argument names whose values never reach the audit log
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "credential", "apikey", "api_key", "privatekey", "private_key", "authorization"}
+
+// RedactArguments returns a copy of args with the values of sensitive keys replaced,
+// descending into nested objects and lists
+func RedactArguments(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if isSensitive(key) {
+			redacted[key] = Redacted
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return RedactArguments(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "nothing sensitive",
+			args: map[string]interface{}{"namespace": "shop", "limit": 10},
+			want: map[string]interface{}{"namespace": "shop", "limit": 10},
+		},
+		{
+			name: "sensitive keys in any case",
+			args: map[string]interface{}{"Password": "hunter2", "bearerToken": "abc", "API_KEY": "xyz", "name": "db"},
+			want: map[string]interface{}{"Password": Redacted, "bearerToken": Redacted, "API_KEY": Redacted, "name": "db"},
+		},
+		{
+			name: "whole nested object under a sensitive key",
+			args: map[string]interface{}{"credentials": map[string]interface{}{"user": "admin"}},
+			want: map[string]interface{}{"credentials": Redacted},
+		},
+		{
+			name: "nested objects",
+			args: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"name": "web",
+					"auth": map[string]interface{}{"privateKey": "-----BEGIN", "user": "admin"},
+				},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"name": "web",
+					"auth": map[string]interface{}{"privateKey": Redacted, "user": "admin"},
+				},
+			},
+		},
+		{
+			name: "objects in lists",
+			args: map[string]interface{}{
+				"env": []interface{}{
+					map[string]interface{}{"name": "DB_HOST", "secretValue": "db.internal"},
+					"plain",
+					[]interface{}{map[string]interface{}{"token": "abc"}},
+				},
+			},
+			want: map[string]interface{}{
+				"env": []interface{}{
+					map[string]interface{}{"name": "DB_HOST", "secretValue": Redacted},
+					"plain",
+					[]interface{}{map[string]interface{}{"token": Redacted}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactArguments(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RedactArguments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactArgumentsCopies(t *testing.T) {
+	args := map[string]interface{}{
+		"password": "hunter2",
+		"spec":     map[string]interface{}{"token": "abc"},
+		"items":    []interface{}{map[string]interface{}{"secret": "s"}},
+	}
+
+	RedactArguments(args)
+
+	want := map[string]interface{}{
+		"password": "hunter2",
+		"spec":     map[string]interface{}{"token": "abc"},
+		"items":    []interface{}{map[string]interface{}{"secret": "s"}},
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("RedactArguments() modified its input: %v", args)
+	}
+}
@@ -2,48 +2,146 @@ package config
 
 import (
 	"os"
-	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server ServerConfig `yaml: "server"`
-	K8s    K8sConfig    `yaml: "kubernetes"`
-	Log    LogConfig    `yaml: "logging"`
+	Server ServerConfig `yaml:"server"`
+	K8s    K8sConfig    `yaml:"kubernetes"`
+	Log    LogConfig    `yaml:"logging"`
+	Safety SafetyConfig `yaml:"safety"`
+	Auth   AuthConfig   `yaml:"auth"`
+	Audit  AuditConfig  `yaml:"audit"`
 }
 
 type ServerConfig struct {
-	Name        string `yaml: "name"`
-	Version     string `yaml: "version"`
-	Description string `yaml: "description"`
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+
+	Transport   string `yaml:"transport"`   // stdio, sse or http (streamable HTTP)
+	ListenAddr  string `yaml:"listenAddr"`  // address the sse and http transports listen on
+	BaseURL     string `yaml:"baseURL"`     // public URL of the sse transport, used in its message endpoint
+	TLSCertFile string `yaml:"tlsCertFile"` // serve HTTPS when both cert and key are set
+	TLSKeyFile  string `yaml:"tlsKeyFile"`
 }
 
+// Transports selectable with server.transport
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
+// K8sConfig selects the clusters the server reads. The namespace scope only limits
+// namespaced resources: nodes belong to no namespace and stay readable whatever the
+// scope, so deny them with safety.deniedKinds if they must stay hidden.
 type K8sConfig struct {
-	ConfigPath string   `yaml: "configPath"`
-	Context    string   `yaml:"context"`
-	Namespaces []string `yaml:"namespaces"`
+	ConfigPath string   `yaml:"configPath"` // kubeconfig file(s); empty uses KUBECONFIG or ~/.kube/config
+	Context    string   `yaml:"context"`    // kubeconfig context; empty uses in-cluster config or current-context
+	Namespaces []string `yaml:"namespaces"` // namespaces the server may read, glob patterns allowed; "*" for all
+
+	// Multi-cluster mode: additional clusters served next to the default one
+	Clusters    []ClusterConfig `yaml:"clusters"`
+	AllContexts bool            `yaml:"allContexts"` // serve every kubeconfig context as a cluster
+}
+
+// ClusterConfig is one entry of the cluster registry
+type ClusterConfig struct {
+	Name       string   `yaml:"name"`       // used in tool arguments and k8s://{cluster}/... URIs
+	ConfigPath string   `yaml:"configPath"` // defaults to kubernetes.configPath
+	Context    string   `yaml:"context"`    // defaults to the kubeconfig's current-context
+	Namespaces []string `yaml:"namespaces"` // defaults to kubernetes.namespaces
 }
 
 type LogConfig struct {
-	Level  string `yaml: "level"`
-	Format string `yaml: "format"`
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+	Output string `yaml:"output"` // stderr or a file path; never stdout, which carries the stdio transport
+}
+
+// SafetyConfig limits what MCP clients may do, independently of the cluster's own RBAC.
+// Empty allow lists allow everything; deny lists always win. The namespaces that may be
+// read are set by kubernetes.namespaces, which every cluster client enforces; it does not
+// cover cluster-scoped kinds such as node, which only deniedKinds can refuse.
+type SafetyConfig struct {
+	ReadOnly      bool     `yaml:"readOnly"`      // refuse every tool that is not read-only
+	AllowedTools  []string `yaml:"allowedTools"`  // tool names that may be called
+	DeniedTools   []string `yaml:"deniedTools"`   // tool names that may never be called
+	DeniedKinds   []string `yaml:"deniedKinds"`   // resource kinds that may never be accessed, e.g. secret
+	RevealSecrets []string `yaml:"revealSecrets"` // "<namespace>/<secret>/<key>" glob patterns whose values may be revealed; secrets are always redacted otherwise
+}
+
+// AuthConfig authenticates callers of the sse and http transports. stdio is always
+// trusted, since only the process that started the server can talk to it.
+type AuthConfig struct {
+	Type        string    `yaml:"type"`        // none, static or jwt
+	TokensFile  string    `yaml:"tokensFile"`  // static: YAML list of {token, user, groups}
+	JWT         JWTConfig `yaml:"jwt"`         // jwt: how tokens are verified and mapped to users
+	Impersonate bool      `yaml:"impersonate"` // send every Kubernetes call as the authenticated caller
+
+	// With impersonation the server's own identity needs the "impersonate" verb on users
+	// and groups. resources/list is then left empty, since it is built from the server's
+	// own view; the k8s:// resource templates still serve reads as the caller.
+}
+
+// JWTConfig verifies bearer JWTs against a local JWKS file, e.g. one exported from an OIDC provider
+type JWTConfig struct {
+	JWKSFile      string   `yaml:"jwksFile"`
+	Issuer        string   `yaml:"issuer"`        // required "iss" claim; empty skips the check
+	Audiences     []string `yaml:"audiences"`     // "aud" must contain one of these; empty skips the check
+	UsernameClaim string   `yaml:"usernameClaim"` // claim holding the Kubernetes user name
+	GroupsClaim   string   `yaml:"groupsClaim"`   // claim holding the Kubernetes groups
+}
+
+// AuditConfig enables the append-only JSONL audit log of MCP requests and the
+// Kubernetes calls made to serve them
+type AuditConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	Path       string `yaml:"path"`       // required when enabled, e.g. /var/log/k8s-mcp-server/audit.jsonl
+	MaxSizeMB  int    `yaml:"maxSizeMB"`  // rotate once the file grows past this size
+	MaxBackups int    `yaml:"maxBackups"` // number of rotated files to keep
 }
 
+// Authentication types selectable with auth.type
+const (
+	AuthNone   = "none"
+	AuthStatic = "static"
+	AuthJWT    = "jwt"
+)
+
 func Load() (*Config, error) {
 	cfg := &Config{
 		Server: ServerConfig{
 			Name:        "k8s-mcp-server",
 			Version:     "1.0.0",
 			Description: "Kubernetes MCP Server for AI-powered cluster management",
+			Transport:   TransportStdio,
+			ListenAddr:  ":8080",
 		},
 		K8s: K8sConfig{
-			ConfigPath: filepath.Join(os.Getenv("HOME"), ".kube", "config"),
 			Namespaces: []string{"default"},
 		},
 		Log: LogConfig{
 			Level:  "info",
 			Format: "json",
+			Output: "stderr",
+		},
+		Safety: SafetyConfig{
+			ReadOnly: true,
+		},
+		Auth: AuthConfig{
+			Type:        AuthNone,
+			Impersonate: true,
+			JWT: JWTConfig{
+				UsernameClaim: "sub",
+				GroupsClaim:   "groups",
+			},
+		},
+		Audit: AuditConfig{
+			MaxSizeMB:  100,
+			MaxBackups: 5,
 		},
 	}
 
@@ -1,28 +1,34 @@
 package logging
 
 import (
+	"fmt"
 	"os"
 	"time"
 
+	"onlylight/k8s-mcp-server/internal/config"
+
 	"github.com/sirupsen/logrus"
 )
 
 type Logger struct {
 	*logrus.Logger
+	file *os.File // log file opened by NewLogger, nil when logging to stderr
 }
 
-func NewLogger(level, format string) *Logger {
+// NewLogger creates a logger from the logging config. Logs go to stderr unless an output
+// file is configured; stdout is reserved for the stdio transport's JSON-RPC stream.
+func NewLogger(cfg config.LogConfig) (*Logger, error) {
 	logger := logrus.New()
 
 	// Set log level
-	logLevel, err := logrus.ParseLevel(level)
+	logLevel, err := logrus.ParseLevel(cfg.Level)
 	if err != nil {
-		logLevel = logrus.InfoLevel
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
 	}
 	logger.SetLevel(logLevel)
 
 	// Set log format
-	if format == "json" {
+	if cfg.Format == "json" {
 		logger.SetFormatter(&logrus.JSONFormatter{
 			TimestampFormat: time.RFC3339,
 		})
@@ -33,49 +39,26 @@ func NewLogger(level, format string) *Logger {
 		})
 	}
 
-	logger.SetOutput(os.Stdout)
-
-	return &Logger{logger}
-}
-
-// LogMCPRequest logs an MCP requests with contextual information
-func (l *Logger) LogMCPRequest(method string, uri string, params interface{}) {
-	l.WithFields(logrus.Fields{
-		"component": "MCP",
-		"method":    method,
-		"uri":       uri,
-		"params":    params,
-	}).Info("Processing MCP request")
-}
-
-// LogMCPResponse logs an MCP response with timing information
-func (l *Logger) LogMCPResponse(method string, duration time.Duration, err error) {
-	fields := logrus.Fields{
-		"component": "MCP",
-		"method":    method,
-		"duration":  duration.String(),
+	switch cfg.Output {
+	case "", "stderr":
+		logger.SetOutput(os.Stderr)
+	default:
+		file, err := os.OpenFile(cfg.Output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		logger.SetOutput(file)
+		return &Logger{Logger: logger, file: file}, nil
 	}
 
-	if err != nil {
-		l.WithFields(fields).WithError(err).Error("MCP request failed")
-	} else {
-		l.WithFields(fields).Info("MCP request completed successfully")
-	}
+	return &Logger{Logger: logger}, nil
 }
 
-// LogK8sOperation logs Kubernetes operations
-func (l *Logger) LogK8sOperation(operation string, namespace string, resource string, duration time.Duration, err error) {
-	fields := logrus.Fields{
-		"component": "Kubernetes",
-		"operation": operation,
-		"namespace": namespace,
-		"resource":  resource,
-		"duration":  duration.String(),
-	}
-
-	if err != nil {
-		l.WithFields(fields).WithError(err).Error("Kubernetes operation failed")
-	} else {
-		l.WithFields(fields).Info("Kubernetes operation completed successfully")
+// Close closes the log file, if there is one. Later entries go to stderr.
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
 	}
+	l.SetOutput(os.Stderr)
+	return l.file.Close()
 }
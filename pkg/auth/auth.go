@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"onlylight/k8s-mcp-server/internal/config"
+)
+
+// ErrUnauthenticated is returned when a request carries no valid credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Identity is the Kubernetes user a caller is mapped to
+type Identity struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Authenticator maps a bearer token to the identity of its holder
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+}
+
+// New creates the authenticator selected by cfg.Type. It returns nil when
+// authentication is disabled.
+func New(cfg config.AuthConfig) (Authenticator, error) {
+	switch cfg.Type {
+	case "", config.AuthNone:
+		return nil, nil
+	case config.AuthStatic:
+		return NewStaticTokenAuthenticator(cfg.TokensFile)
+	case config.AuthJWT:
+		return NewJWTAuthenticator(cfg.JWT)
+	default:
+		return nil, fmt.Errorf("unsupported auth type %q", cfg.Type)
+	}
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer <token>" header
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the caller's identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFrom returns the caller's identity, if the request was authenticated
+func IdentityFrom(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/config"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// signatureAlgorithms are the JWS algorithms accepted for bearer tokens
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTAuthenticator verifies signed JWTs against a locally configured JWKS and maps
+// their claims to a Kubernetes user and groups
+type JWTAuthenticator struct {
+	keys     jose.JSONWebKeySet
+	expected jwt.Expected
+	cfg      config.JWTConfig
+}
+
+// NewJWTAuthenticator loads the JWKS file named in cfg
+func NewJWTAuthenticator(cfg config.JWTConfig) (*JWTAuthenticator, error) {
+	if cfg.JWKSFile == "" {
+		return nil, fmt.Errorf("jwt authentication requires auth.jwt.jwksFile")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "sub"
+	}
+
+	data, err := os.ReadFile(cfg.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	a := &JWTAuthenticator{
+		expected: jwt.Expected{Issuer: cfg.Issuer, AnyAudience: cfg.Audiences},
+		cfg:      cfg,
+	}
+	if err := json.Unmarshal(data, &a.keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", cfg.JWKSFile, err)
+	}
+	if len(a.keys.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s contains no keys", cfg.JWKSFile)
+	}
+
+	return a, nil
+}
+
+// Authenticate verifies the token's signature, issuer, audience and lifetime
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	parsed, err := jwt.ParseSigned(token, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token: %v", ErrUnauthenticated, err)
+	}
+
+	var claims jwt.Claims
+	var custom map[string]interface{}
+	if err := parsed.Claims(a.verificationKeys(parsed), &claims, &custom); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: token has no expiry", ErrUnauthenticated)
+	}
+	if err := claims.Validate(a.expected.WithTime(time.Now())); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	user, _ := custom[a.cfg.UsernameClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("%w: token has no %q claim", ErrUnauthenticated, a.cfg.UsernameClaim)
+	}
+
+	identity := &Identity{User: user}
+	if a.cfg.GroupsClaim != "" {
+		switch groups := custom[a.cfg.GroupsClaim].(type) {
+		case string:
+			identity.Groups = []string{groups}
+		case []interface{}:
+			for _, group := range groups {
+				if name, ok := group.(string); ok {
+					identity.Groups = append(identity.Groups, name)
+				}
+			}
+		}
+	}
+
+	return identity, nil
+}
+
+// verificationKeys returns the key set, which selects the key named by the token's kid
+// header. Tokens without a kid are only accepted when the set holds a single key.
+func (a *JWTAuthenticator) verificationKeys(token *jwt.JSONWebToken) interface{} {
+	if len(a.keys.Keys) == 1 {
+		for _, header := range token.Headers {
+			if header.KeyID == "" {
+				return a.keys.Keys[0].Key
+			}
+		}
+	}
+	return a.keys
+}
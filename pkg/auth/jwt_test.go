@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/config"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// testJWTAuthenticator writes the public halves of keys to a JWKS file, with the key IDs
+// from kids unless it is nil, and loads an authenticator for it
+func testJWTAuthenticator(t *testing.T, cfg config.JWTConfig, kids []string, keys ...*ecdsa.PrivateKey) *JWTAuthenticator {
+	t.Helper()
+
+	var set jose.JSONWebKeySet
+	for i, key := range keys {
+		jwk := jose.JSONWebKey{Key: &key.PublicKey, Algorithm: string(jose.ES256), Use: "sig"}
+		if kids != nil {
+			jwk.KeyID = kids[i]
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	cfg.JWKSFile = filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(cfg.JWKSFile, data, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(cfg)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+	return authenticator
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// signToken signs claims with key, naming kid in the header unless it is empty
+func signToken(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+
+	options := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		options = options.WithHeader(jose.HeaderKey("kid"), kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, options)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	current, other := newTestKey(t), newTestKey(t)
+	now := time.Now()
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":    "https://issuer.example",
+			"aud":    []string{"k8s-mcp"},
+			"sub":    "alice",
+			"exp":    now.Add(time.Hour).Unix(),
+			"iat":    now.Unix(),
+			"groups": []string{"platform", "oncall"},
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(c, name)
+			} else {
+				c[name] = value
+			}
+		}
+		return c
+	}
+	cfg := config.JWTConfig{Issuer: "https://issuer.example", Audiences: []string{"k8s-mcp"}, GroupsClaim: "groups"}
+	keySet := testJWTAuthenticator(t, cfg, []string{"current", "other"}, current, other)
+	singleKey := testJWTAuthenticator(t, cfg, nil, current)
+
+	tests := []struct {
+		name          string
+		authenticator *JWTAuthenticator
+		token         string
+		want          *Identity
+	}{
+		{name: "key selected by kid", authenticator: keySet, token: signToken(t, current, "current", claims(nil)), want: &Identity{User: "alice", Groups: []string{"platform", "oncall"}}},
+		{name: "second key selected by kid", authenticator: keySet, token: signToken(t, other, "other", claims(nil)), want: &Identity{User: "alice", Groups: []string{"platform", "oncall"}}},
+		{name: "kid naming another key", authenticator: keySet, token: signToken(t, current, "other", claims(nil))},
+		{name: "unknown kid", authenticator: keySet, token: signToken(t, current, "retired", claims(nil))},
+		{name: "no kid with several keys", authenticator: keySet, token: signToken(t, current, "", claims(nil))},
+		{name: "no kid with a single key", authenticator: singleKey, token: signToken(t, current, "", claims(nil)), want: &Identity{User: "alice", Groups: []string{"platform", "oncall"}}},
+		{name: "no kid signed by an unknown key", authenticator: singleKey, token: signToken(t, other, "", claims(nil))},
+
+		{name: "expired", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()}))},
+		{name: "no exp", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"exp": nil}))},
+		{name: "not yet valid", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()}))},
+		{name: "issuer mismatch", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"iss": "https://evil.example"}))},
+		{name: "no issuer", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"iss": nil}))},
+		{name: "audience mismatch", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"aud": []string{"other-service"}}))},
+		{name: "one of several audiences", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"aud": []string{"other-service", "k8s-mcp"}})), want: &Identity{User: "alice", Groups: []string{"platform", "oncall"}}},
+		{name: "no user", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"sub": nil}))},
+
+		{name: "groups as a single string", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"groups": "platform"})), want: &Identity{User: "alice", Groups: []string{"platform"}}},
+		{name: "groups with non-string entries", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"groups": []any{"platform", 7, true}})), want: &Identity{User: "alice", Groups: []string{"platform"}}},
+		{name: "groups of another type", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"groups": map[string]string{"team": "platform"}})), want: &Identity{User: "alice"}},
+		{name: "no groups", authenticator: keySet, token: signToken(t, current, "current", claims(map[string]any{"groups": nil})), want: &Identity{User: "alice"}},
+
+		{name: "empty token", authenticator: keySet, token: ""},
+		{name: "malformed token", authenticator: keySet, token: "not.a.jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.authenticator.Authenticate(context.Background(), tt.token)
+			if tt.want == nil {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("Authenticate() = %+v, %v, want ErrUnauthenticated", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if got.User != tt.want.User || !slices.Equal(got.Groups, tt.want.Groups) {
+				t.Errorf("Authenticate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticateClaims(t *testing.T) {
+	key := newTestKey(t)
+	exp := time.Now().Add(time.Hour).Unix()
+
+	t.Run("username and groups from custom claims", func(t *testing.T) {
+		authenticator := testJWTAuthenticator(t, config.JWTConfig{UsernameClaim: "email", GroupsClaim: "roles"}, nil, key)
+		token := signToken(t, key, "", map[string]any{"sub": "1234", "email": "alice@example.com", "roles": []string{"admin"}, "exp": exp})
+		got, err := authenticator.Authenticate(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if got.User != "alice@example.com" || !slices.Equal(got.Groups, []string{"admin"}) {
+			t.Errorf("Authenticate() = %+v, want alice@example.com in admin", got)
+		}
+	})
+
+	t.Run("groups ignored without a groups claim", func(t *testing.T) {
+		authenticator := testJWTAuthenticator(t, config.JWTConfig{}, nil, key)
+		token := signToken(t, key, "", map[string]any{"sub": "alice", "groups": []string{"admin"}, "exp": exp})
+		got, err := authenticator.Authenticate(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		if got.User != "alice" || got.Groups != nil {
+			t.Errorf("Authenticate() = %+v, want alice without groups", got)
+		}
+	})
+
+	t.Run("issuer and audience unchecked when not configured", func(t *testing.T) {
+		authenticator := testJWTAuthenticator(t, config.JWTConfig{}, nil, key)
+		token := signToken(t, key, "", map[string]any{"sub": "alice", "iss": "anyone", "aud": "anything", "exp": exp})
+		if _, err := authenticator.Authenticate(context.Background(), token); err != nil {
+			t.Errorf("Authenticate() error = %v", err)
+		}
+	})
+}
+
+func TestNewJWTAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.json")
+	if err := os.WriteFile(empty, []byte(`{"keys":[]}`), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`not json`), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	for name, file := range map[string]string{"no file": "", "missing file": filepath.Join(dir, "missing.json"), "no keys": empty, "invalid": invalid} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewJWTAuthenticator(config.JWTConfig{JWKSFile: file}); err == nil {
+				t.Error("NewJWTAuthenticator() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTokensFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write tokens file: %v", err)
+	}
+	return path
+}
+
+func TestStaticTokenAuthenticate(t *testing.T) {
+	authenticator, err := NewStaticTokenAuthenticator(writeTokensFile(t, `
+- token: alice-token
+  user: alice
+  groups: [platform]
+- token: bob-token
+  user: bob
+`))
+	if err != nil {
+		t.Fatalf("NewStaticTokenAuthenticator() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          *Identity
+	}{
+		{name: "hit", authorization: "Bearer alice-token", want: &Identity{User: "alice", Groups: []string{"platform"}}},
+		{name: "hit without groups", authorization: "Bearer bob-token", want: &Identity{User: "bob"}},
+		{name: "lower-case scheme", authorization: "bearer bob-token", want: &Identity{User: "bob"}},
+		{name: "miss", authorization: "Bearer mallory-token"},
+		{name: "prefix of a token", authorization: "Bearer alice"},
+		{name: "blank header", authorization: ""},
+		{name: "blank token", authorization: "Bearer  "},
+		{name: "other scheme", authorization: "Basic alice-token"},
+		{name: "token without scheme", authorization: "alice-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, "/sse", nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			got, err := authenticator.Authenticate(context.Background(), BearerToken(request))
+			if tt.want == nil {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("Authenticate() = %+v, %v, want ErrUnauthenticated", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if got.User != tt.want.User || !slices.Equal(got.Groups, tt.want.Groups) {
+				t.Errorf("Authenticate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStaticTokenAuthenticator(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "entry without user", content: "- token: alice-token\n"},
+		{name: "entry without token", content: "- user: alice\n"},
+		{name: "not a list", content: "token: alice-token\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStaticTokenAuthenticator(writeTokensFile(t, tt.content)); err == nil {
+				t.Error("NewStaticTokenAuthenticator() succeeded, want an error")
+			}
+		})
+	}
+
+	if _, err := NewStaticTokenAuthenticator(""); err == nil {
+		t.Error("NewStaticTokenAuthenticator() without a path succeeded, want an error")
+	}
+}
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/audit"
+
+	"github.com/sirupsen/logrus"
+)
+
+// auditRoundTripper records every API request made while serving an MCP request, using
+// the audit trail attached to the request context. Requests without a trail, such as the
+// resource registry's own informers, are passed through unrecorded.
+type auditRoundTripper struct {
+	cluster  string // the name the cluster is registered under, as in the MCP records
+	logger   *logrus.Logger
+	delegate http.RoundTripper
+}
+
+func newAuditRoundTripper(cluster string, logger *logrus.Logger) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &auditRoundTripper{cluster: cluster, logger: logger, delegate: rt}
+	}
+}
+
+func (rt *auditRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	trail, ok := audit.FromContext(req.Context())
+	if !ok {
+		return rt.delegate.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := rt.delegate.RoundTrip(req)
+
+	event := apiRequestEvent(req)
+	event.Kind = audit.KindKubernetes
+	event.Cluster = rt.cluster
+	event.DurationMs = audit.Milliseconds(time.Since(start))
+	event.Outcome = audit.OutcomeSuccess
+	switch {
+	case err != nil:
+		event.Outcome = audit.OutcomeError
+		event.Error = err.Error()
+	case resp.StatusCode >= 400:
+		event.StatusCode = resp.StatusCode
+		event.Outcome = audit.OutcomeError
+		event.Error = resp.Status
+	default:
+		event.StatusCode = resp.StatusCode
+	}
+
+	if recordErr := trail.Record(event); recordErr != nil {
+		rt.logger.Errorf("Failed to write audit record for %s %s: %v", req.Method, req.URL.Path, recordErr)
+	}
+
+	return resp, err
+}
+
+// apiRequestEvent derives the verb, resource, namespace and name of an API request from
+// its method and path, e.g. GET /api/v1/namespaces/web/pods/api-0/log
+func apiRequestEvent(req *http.Request) audit.Event {
+	var event audit.Event
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var rest []string
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		rest = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		rest = parts[3:]
+	default:
+		// Non-resource URLs such as /version
+		event.Verb = strings.ToLower(req.Method)
+		event.Resource = req.URL.Path
+		return event
+	}
+
+	if len(rest) >= 3 && rest[0] == "namespaces" {
+		event.Namespace = rest[1]
+		rest = rest[2:]
+	}
+	if len(rest) > 0 {
+		event.Resource = rest[0]
+	}
+	if len(rest) > 1 {
+		event.Name = rest[1]
+	}
+	if len(rest) > 2 {
+		event.Resource += "/" + strings.Join(rest[2:], "/")
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case req.URL.Query().Get("watch") == "true" || req.URL.Query().Get("watch") == "1":
+			event.Verb = "watch"
+		case event.Name == "":
+			event.Verb = "list"
+		default:
+			event.Verb = "get"
+		}
+	case http.MethodPost:
+		event.Verb = "create"
+	case http.MethodPut:
+		event.Verb = "update"
+	case http.MethodPatch:
+		event.Verb = "patch"
+	case http.MethodDelete:
+		event.Verb = "delete"
+		if event.Name == "" {
+			event.Verb = "deletecollection"
+		}
+	default:
+		event.Verb = strings.ToLower(req.Method)
+	}
+
+	return event
+}
@@ -0,0 +1,345 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// defaultBackoffLimit is what the API server sets when a job leaves backoffLimit unset
+	defaultBackoffLimit = 6
+
+	// maxRecentJobs bounds how many spawned jobs GetCronJob reports
+	maxRecentJobs = 10
+
+	// maxJobPodFailures bounds how many failed containers GetJob reports
+	maxJobPodFailures = 10
+)
+
+func (c *Client) ListJobs(ctx context.Context, namespace string, opts ListOptions) (*ListPage[JobInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listJobs)
+}
+
+func (c *Client) listJobs(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[JobInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := kube.BatchV1().Jobs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs in namespace %s: %w", namespace, err)
+	}
+
+	var jobInfos []JobInfo
+	for i := range jobs.Items {
+		jobInfos = append(jobInfos, newJobInfo(&jobs.Items[i]))
+	}
+
+	return newListPage(jobInfos, &jobs.ListMeta), nil
+}
+
+// GetJob returns a job with the reason it failed, the containers that exited with an
+// error, its conditions and recent events
+func (c *Client) GetJob(ctx context.Context, namespace, name string) (*JobDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := kube.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s/%s: %w", namespace, name, err)
+	}
+
+	details := &JobDetails{
+		JobInfo: newJobInfo(job),
+		Events:  c.recentEventsOrNil(ctx, "Job", job.Namespace, job.Name),
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		details.Conditions = append(details.Conditions, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		if condition.Type == batchv1.JobFailed {
+			details.FailureReason = condition.Reason
+			details.FailureMessage = condition.Message
+		}
+	}
+
+	// Without access to the pods the job is still worth showing, just without the
+	// containers that failed
+	if job.Status.Failed > 0 {
+		if details.PodFailures, err = c.getJobPodFailures(ctx, job); err != nil {
+			c.logger.WithContext(ctx).Warnf("Failed to get failed containers of job %s/%s: %v", job.Namespace, job.Name, err)
+		}
+	}
+
+	return details, nil
+}
+
+// getJobPodFailures collects the containers of a job's pods that terminated with a
+// non-zero exit code, including earlier runs of containers that were restarted
+func (c *Client) getJobPodFailures(ctx context.Context, job *batchv1.Job) ([]JobPodFailure, error) {
+	// Pods carry the job-name label on every Kubernetes version; the selector is only
+	// missing on jobs that were never admitted by an API server, such as test fixtures
+	selector := labels.SelectorFromSet(labels.Set{"job-name": job.Name})
+	if job.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(job.Spec.Selector); err != nil {
+			return nil, fmt.Errorf("invalid selector on job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kube.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	failures := []JobPodFailure{}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated == nil || terminated.ExitCode == 0 {
+					continue
+				}
+				failures = append(failures, JobPodFailure{
+					Pod:       pod.Name,
+					Container: status.Name,
+					Reason:    terminated.Reason,
+					ExitCode:  terminated.ExitCode,
+					Message:   terminated.Message,
+				})
+				if len(failures) == maxJobPodFailures {
+					return failures, nil
+				}
+			}
+		}
+	}
+
+	return failures, nil
+}
+
+func (c *Client) ListCronJobs(ctx context.Context, namespace string, opts ListOptions) (*ListPage[CronJobInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listCronJobs)
+}
+
+func (c *Client) listCronJobs(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[CronJobInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cronjobs, err := kube.BatchV1().CronJobs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs in namespace %s: %w", namespace, err)
+	}
+
+	now := c.now()
+	var cronjobInfos []CronJobInfo
+	for i := range cronjobs.Items {
+		cronjobInfos = append(cronjobInfos, newCronJobInfo(&cronjobs.Items[i], now))
+	}
+
+	return newListPage(cronjobInfos, &cronjobs.ListMeta), nil
+}
+
+// GetCronJob returns a cronjob with its next run, the most recent jobs it spawned and
+// how many of them failed in a row. The controller deletes failed jobs beyond
+// failedJobsHistoryLimit, so the run of failures is counted up to that limit only.
+func (c *Client) GetCronJob(ctx context.Context, namespace, name string) (*CronJobDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cronjob, err := kube.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjob %s/%s: %w", namespace, name, err)
+	}
+
+	details := &CronJobDetails{
+		CronJobInfo:                newCronJobInfo(cronjob, c.now()),
+		StartingDeadlineSeconds:    cronjob.Spec.StartingDeadlineSeconds,
+		SuccessfulJobsHistoryLimit: 3,
+		FailedJobsHistoryLimit:     1,
+		Events:                     c.recentEventsOrNil(ctx, "CronJob", cronjob.Namespace, cronjob.Name),
+	}
+	if cronjob.Spec.SuccessfulJobsHistoryLimit != nil {
+		details.SuccessfulJobsHistoryLimit = *cronjob.Spec.SuccessfulJobsHistoryLimit
+	}
+	if cronjob.Spec.FailedJobsHistoryLimit != nil {
+		details.FailedJobsHistoryLimit = *cronjob.Spec.FailedJobsHistoryLimit
+	}
+
+	// Spawned jobs carry the labels of the job template, which narrow the list; without
+	// template labels every job in the namespace is listed. Either way the controller
+	// reference decides, so jobs of an earlier cronjob of the same name are left out.
+	var listOptions metav1.ListOptions
+	if len(cronjob.Spec.JobTemplate.Labels) > 0 {
+		listOptions.LabelSelector = labels.SelectorFromSet(cronjob.Spec.JobTemplate.Labels).String()
+	}
+	jobs, err := kube.BatchV1().Jobs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs of cronjob %s/%s: %w", namespace, name, err)
+	}
+
+	var spawned []*batchv1.Job
+	for i := range jobs.Items {
+		if owner := metav1.GetControllerOf(&jobs.Items[i]); owner != nil && owner.UID == cronjob.UID {
+			spawned = append(spawned, &jobs.Items[i])
+		}
+	}
+	sort.Slice(spawned, func(i, j int) bool {
+		if !spawned[i].CreationTimestamp.Equal(&spawned[j].CreationTimestamp) {
+			return spawned[j].CreationTimestamp.Before(&spawned[i].CreationTimestamp)
+		}
+		return spawned[i].Name > spawned[j].Name
+	})
+
+	details.RecentJobs = []JobInfo{}
+	countingFailures := true
+	for _, job := range spawned {
+		info := newJobInfo(job)
+		if len(details.RecentJobs) < maxRecentJobs {
+			details.RecentJobs = append(details.RecentJobs, info)
+		}
+
+		// Unfinished jobs neither break nor extend a run of failures
+		switch {
+		case !countingFailures:
+		case info.Status == "Failed":
+			details.ConsecutiveFailures++
+		case info.Status == "Complete":
+			countingFailures = false
+		}
+	}
+
+	return details, nil
+}
+
+func newJobInfo(job *batchv1.Job) JobInfo {
+	info := JobInfo{
+		Name:                  job.Name,
+		Namespace:             job.Namespace,
+		Status:                jobStatus(job),
+		Completions:           desiredReplicas(job.Spec.Completions),
+		Parallelism:           desiredReplicas(job.Spec.Parallelism),
+		Active:                job.Status.Active,
+		Succeeded:             job.Status.Succeeded,
+		Failed:                job.Status.Failed,
+		BackoffLimit:          defaultBackoffLimit,
+		ActiveDeadlineSeconds: job.Spec.ActiveDeadlineSeconds,
+		Labels:                job.Labels,
+		CreatedAt:             job.CreationTimestamp.Time,
+	}
+	if job.Spec.BackoffLimit != nil {
+		info.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		info.Owner = owner.Name
+	}
+	if job.Status.StartTime != nil {
+		info.StartTime = &job.Status.StartTime.Time
+	}
+	if job.Status.CompletionTime != nil {
+		info.CompletionTime = &job.Status.CompletionTime.Time
+	}
+	return info
+}
+
+// jobStatus summarizes a job's conditions and active pods in one word
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		case batchv1.JobSuspended:
+			return "Suspended"
+		}
+	}
+	if job.Status.Active > 0 {
+		return "Running"
+	}
+	return "Pending"
+}
+
+func newCronJobInfo(cronjob *batchv1.CronJob, now time.Time) CronJobInfo {
+	info := CronJobInfo{
+		Name:              cronjob.Name,
+		Namespace:         cronjob.Namespace,
+		Schedule:          cronjob.Spec.Schedule,
+		Suspended:         cronjob.Spec.Suspend != nil && *cronjob.Spec.Suspend,
+		ConcurrencyPolicy: string(cronjob.Spec.ConcurrencyPolicy),
+		Active:            len(cronjob.Status.Active),
+		Labels:            cronjob.Labels,
+		CreatedAt:         cronjob.CreationTimestamp.Time,
+	}
+	if cronjob.Spec.TimeZone != nil {
+		info.TimeZone = *cronjob.Spec.TimeZone
+	}
+	if info.ConcurrencyPolicy == "" {
+		info.ConcurrencyPolicy = string(batchv1.AllowConcurrent)
+	}
+	if cronjob.Status.LastScheduleTime != nil {
+		info.LastScheduleTime = &cronjob.Status.LastScheduleTime.Time
+	}
+	if cronjob.Status.LastSuccessfulTime != nil {
+		info.LastSuccessfulTime = &cronjob.Status.LastSuccessfulTime.Time
+	}
+
+	next, err := nextScheduleTime(info.Schedule, info.TimeZone, now.UTC())
+	if err != nil {
+		info.ScheduleError = err.Error()
+	} else if !info.Suspended {
+		info.NextScheduleTime = &next
+	}
+
+	return info
+}
+
+// nextScheduleTime works out the first run of a cron schedule after now. The schedule is
+// parsed like the CronJob controller does, so descriptors such as @hourly and a
+// CRON_TZ= prefix are accepted too.
+func nextScheduleTime(schedule, timeZone string, now time.Time) (time.Time, error) {
+	spec := schedule
+	if timeZone != "" {
+		spec = fmt.Sprintf("TZ=%s %s", timeZone, schedule)
+	}
+
+	parsed, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	next := parsed.Next(now)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("schedule %q never runs", spec)
+	}
+	return next, nil
+}
@@ -2,10 +2,11 @@ package k8s
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
+
 	"onlylight/k8s-mcp-server/pkg/types"
-	"path/filepath"
 
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
@@ -13,30 +14,71 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	"k8s.io/utils/lru"
 )
 
+// Client reads one cluster through a kubernetes.Interface
 type Client struct {
-	clientset *kubernetes.Clientset
-	logger    *logrus.Logger
+	clientset   kubernetes.Interface
+	config      *rest.Config // nil for clients built from a clientset, which can't impersonate
+	logger      *logrus.Logger
+	contextName string
+	scope       NamespaceScope
+	now         func() time.Time // clock for values derived from the current time, e.g. the next cronjob run
+
+	// impersonated caches one clientset per impersonated caller. The mutex makes
+	// concurrent requests of one caller share a clientset.
+	impersonatedMu sync.Mutex
+	impersonated   *lru.Cache
 }
 
-func NewClient(configPath string, logger *logrus.Logger) (*Client, error) {
-	config, err := buildConfig(configPath)
+// NewClient creates a client for the given kubeconfig context. An empty contextName
+// uses the in-cluster config when available and the kubeconfig's current-context otherwise.
+// cluster is the name the client is registered under, which its API calls are audited
+// with; an empty cluster uses the context name. The client only reads namespaces matching
+// the namespaces patterns; none means all.
+func NewClient(cluster, configPath, contextName string, namespaces []string, logger *logrus.Logger) (*Client, error) {
+	config, contextName, err := buildConfig(configPath, contextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
 	}
 
+	if cluster == "" {
+		cluster = contextName
+	}
+	config.Wrap(newAuditRoundTripper(cluster, logger))
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
 	}
 
+	client := NewClientFromClientset(clientset, contextName, namespaces, logger)
+	client.config = config
+	return client, nil
+}
+
+// NewClientFromClientset creates a client on top of an existing clientset, such as
+// k8s.io/client-go/kubernetes/fake in tests. Such a client cannot impersonate callers.
+func NewClientFromClientset(clientset kubernetes.Interface, contextName string, namespaces []string, logger *logrus.Logger) *Client {
 	return &Client{
-		clientset: clientset,
-		logger:    logger,
-	}, nil
+		clientset:    clientset,
+		logger:       logger,
+		impersonated: lru.New(maxImpersonatedClients),
+		contextName:  contextName,
+		scope:        NewNamespaceScope(namespaces),
+		now:          time.Now,
+	}
+}
+
+// Scope returns the namespaces the client may read
+func (c *Client) Scope() NamespaceScope {
+	return c.scope
+}
+
+// ContextName returns the kubeconfig context the client talks to, or "in-cluster"
+func (c *Client) ContextName() string {
+	return c.contextName
 }
 
 func (c *Client) HealthCheck(ctx context.Context) error {
@@ -63,8 +105,16 @@ func (c *Client) GetClusterInfo(ctx context.Context) (map[string]interface{}, er
 	return info, nil
 }
 
-func (c *Client) ListPods(ctx context.Context, namespace string) ([]PodInfo, error) {
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListPods(ctx context.Context, namespace string, opts ListOptions) (*ListPage[PodInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listPods)
+}
+
+func (c *Client) listPods(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[PodInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kube.CoreV1().Pods(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
 	}
@@ -83,11 +133,19 @@ func (c *Client) ListPods(ctx context.Context, namespace string) ([]PodInfo, err
 		})
 	}
 
-	return podInfos, nil
+	return newListPage(podInfos, &pods.ListMeta), nil
 }
 
-func (c *Client) ListServices(ctx context.Context, namespace string) ([]ServiceInfo, error) {
-	services, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListServices(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ServiceInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listServices)
+}
+
+func (c *Client) listServices(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[ServiceInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	services, err := kube.CoreV1().Services(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list services in namespace %s: %w", namespace, err)
 	}
@@ -115,11 +173,19 @@ func (c *Client) ListServices(ctx context.Context, namespace string) ([]ServiceI
 		})
 	}
 
-	return serviceInfos, nil
+	return newListPage(serviceInfos, &services.ListMeta), nil
+}
+
+func (c *Client) ListDeployments(ctx context.Context, namespace string, opts ListOptions) (*ListPage[DeploymentInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listDeployments)
 }
 
-func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]DeploymentInfo, error) {
-	deployments, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) listDeployments(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[DeploymentInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := kube.AppsV1().Deployments(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployments in namespace %s: %w", namespace, err)
 	}
@@ -134,7 +200,7 @@ func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]Deplo
 		deploymentInfos = append(deploymentInfos, DeploymentInfo{
 			Name:            deploy.Name,
 			Namespace:       deploy.Namespace,
-			TotalReplicas:   *deploy.Spec.Replicas,
+			TotalReplicas:   desiredReplicas(deploy.Spec.Replicas),
 			ReadyReplicas:   deploy.Status.ReadyReplicas,
 			UpdatedReplicas: deploy.Status.UpdatedReplicas,
 			Labels:          deploy.Labels,
@@ -143,11 +209,19 @@ func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]Deplo
 		})
 	}
 
-	return deploymentInfos, nil
+	return newListPage(deploymentInfos, &deployments.ListMeta), nil
 }
 
-func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]ConfigMapInfo, error) {
-	configmaps, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+func (c *Client) ListConfigMaps(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ConfigMapInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listConfigMaps)
+}
+
+func (c *Client) listConfigMaps(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[ConfigMapInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configmaps, err := kube.CoreV1().ConfigMaps(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list configmaps in namespace %s: %w", namespace, err)
 	}
@@ -155,26 +229,40 @@ func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]Config
 	var configmapInfos []ConfigMapInfo
 	for _, cm := range configmaps.Items {
 		configmapInfo := ConfigMapInfo{
-			Name:      cm.Name,
-			Namespace: cm.Namespace,
-			Data:      cm.Data,
-			Labels:    cm.Labels,
-			CreatedAt: cm.CreationTimestamp.Time,
+			Name:       cm.Name,
+			Namespace:  cm.Namespace,
+			Data:       cm.Data,
+			BinaryData: binaryDataSizes(cm.BinaryData),
+			Labels:     cm.Labels,
+			CreatedAt:  cm.CreationTimestamp.Time,
 		}
 		configmapInfos = append(configmapInfos, configmapInfo)
 	}
 
-	return configmapInfos, nil
+	return newListPage(configmapInfos, &configmaps.ListMeta), nil
 }
 
-func (c *Client) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
-	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+func (c *Client) ListNamespaces(ctx context.Context, opts ListOptions) (*ListPage[NamespaceInfo], error) {
+	listOptions, err := opts.toListOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := kube.CoreV1().Namespaces().List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
+	// Namespaces outside the scope are dropped, so a page may hold fewer than Limit items
 	var namespaceInfos []NamespaceInfo
 	for _, ns := range namespaces.Items {
+		if !c.scope.Contains(ns.Name) {
+			continue
+		}
 		namespaceInfo := NamespaceInfo{
 			Name:      ns.Name,
 			Status:    string(ns.Status.Phase),
@@ -184,40 +272,61 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
 		namespaceInfos = append(namespaceInfos, namespaceInfo)
 	}
 
-	return namespaceInfos, nil
+	return newListPage(namespaceInfos, &namespaces.ListMeta), nil
 }
 
-func (c *Client) GetResource(ctx context.Context, identifier *types.ResourceIdentifier) (string, error) {
+// GetResource returns the details of the object identifier points to: a *PodDetails,
+// *ServiceDetails, *DeploymentDetails, *StatefulSetDetails, *DaemonSetDetails,
+// *ReplicaSetDetails, *JobDetails, *CronJobDetails, *ConfigMapInfo, *SecretInfo,
+// *NamespaceInfo or *NodeDetails
+func (c *Client) GetResource(ctx context.Context, identifier *types.ResourceIdentifier) (any, error) {
 	switch identifier.Type {
 	case types.ResourceTypePod:
-		return c.getPodDetails(ctx, identifier.Namespace, identifier.Name)
+		return c.GetPod(ctx, identifier.Namespace, identifier.Name)
 	case types.ResourceTypeService:
-		return c.getServiceDetails(ctx, identifier.Namespace, identifier.Name)
+		return c.GetService(ctx, identifier.Namespace, identifier.Name)
 	case types.ResourceTypeDeployment:
-		return c.getDeploymentDetails(ctx, identifier.Namespace, identifier.Name)
+		return c.GetDeployment(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeStatefulSet:
+		return c.GetStatefulSet(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeDaemonSet:
+		return c.GetDaemonSet(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeReplicaSet:
+		return c.GetReplicaSet(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeJob:
+		return c.GetJob(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeCronJob:
+		return c.GetCronJob(ctx, identifier.Namespace, identifier.Name)
 	case types.ResourceTypeConfigMap:
-		return c.getConfigMapDetails(ctx, identifier.Namespace, identifier.Name)
+		return c.GetConfigMap(ctx, identifier.Namespace, identifier.Name)
+	case types.ResourceTypeSecret:
+		return c.GetSecret(ctx, identifier.Namespace, identifier.Name, nil)
 	case types.ResourceTypeNamespace:
-		return c.getNamespaceDetails(ctx, identifier.Name)
+		return c.GetNamespace(ctx, identifier.Name)
+	case types.ResourceTypeNode:
+		return c.GetNode(ctx, identifier.Name)
 	default:
-		return "", fmt.Errorf("unsupported resource type: %s", identifier.Type)
+		return nil, fmt.Errorf("unsupported resource type: %s", identifier.Type)
 	}
 }
 
-func (c *Client) getPodDetails(ctx context.Context, namespace, name string) (string, error) {
-	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+// GetPod returns a pod with its containers, conditions and recent events
+func (c *Client) GetPod(ctx context.Context, namespace, name string) (*PodDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+		return nil, err
+	}
+	pod, err := kube.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
 	}
 
-	// Create detailed pod information
-	podDetail := struct {
-		*PodInfo
-		Containers []ContainerInfo `json:"containers"`
-		Events     []string        `json:"recentEvents"`
-		Conditions []string        `json:"conditions"`
-	}{
-		PodInfo: &PodInfo{
+	return &PodDetails{
+		PodInfo: PodInfo{
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 			Status:    string(pod.Status.Phase),
@@ -228,21 +337,24 @@ func (c *Client) getPodDetails(ctx context.Context, namespace, name string) (str
 			Restarts:  getTotalRestarts(pod),
 		},
 		Containers: getContainerInfo(pod),
+		Events:     c.recentEventsOrNil(ctx, "Pod", pod.Namespace, pod.Name),
 		Conditions: getPodConditions(pod),
+	}, nil
+}
+
+// GetService returns a service with its selector, endpoints and recent events
+func (c *Client) GetService(ctx context.Context, namespace, name string) (*ServiceDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
 	}
 
-	data, err := json.MarshalIndent(podDetail, "", "  ")
+	kube, err := c.kube(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal pod details: %w", err)
+		return nil, err
 	}
-
-	return string(data), nil
-}
-
-func (c *Client) getServiceDetails(ctx context.Context, namespace, name string) (string, error) {
-	service, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	service, err := kube.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
 	}
 
 	var ports []ServicePort
@@ -255,12 +367,32 @@ func (c *Client) getServiceDetails(ctx context.Context, namespace, name string)
 		})
 	}
 
-	serviceDetail := struct {
-		*ServiceInfo
-		Selector  map[string]string `json:"selector"`
-		Endpoints []string          `json:"endpoints"`
-	}{
-		ServiceInfo: &ServiceInfo{
+	// Endpoints and matching pods only add to the service, so failing to read them, e.g.
+	// for lack of RBAC on endpointslices or pods, leaves those fields unset
+	endpoints, err := c.getServiceEndpoints(ctx, service.Namespace, service.Name)
+	if err != nil {
+		c.logger.WithContext(ctx).Warnf("Failed to get endpoints: %v", err)
+	}
+
+	readyEndpoints := 0
+	for _, endpoint := range endpoints {
+		if endpoint.Ready {
+			readyEndpoints++
+		}
+	}
+
+	// Services without a selector have manually managed endpoints, so there is nothing to match
+	var matchingPods *int
+	if len(service.Spec.Selector) > 0 {
+		if count, err := c.countSelectedPods(ctx, service.Namespace, service.Spec.Selector); err != nil {
+			c.logger.WithContext(ctx).Warnf("Failed to count pods matching the selector: %v", err)
+		} else {
+			matchingPods = &count
+		}
+	}
+
+	return &ServiceDetails{
+		ServiceInfo: ServiceInfo{
 			Name:      service.Name,
 			Namespace: service.Namespace,
 			Type:      string(service.Spec.Type),
@@ -269,21 +401,27 @@ func (c *Client) getServiceDetails(ctx context.Context, namespace, name string)
 			Labels:    service.Labels,
 			CreatedAt: service.CreationTimestamp.Time,
 		},
-		Selector: service.Spec.Selector,
+		Selector:       service.Spec.Selector,
+		Endpoints:      endpoints,
+		ReadyEndpoints: readyEndpoints,
+		MatchingPods:   matchingPods,
+		Events:         c.recentEventsOrNil(ctx, "Service", service.Namespace, service.Name),
+	}, nil
+}
+
+// GetDeployment returns a deployment with its selector, conditions and recent events
+func (c *Client) GetDeployment(ctx context.Context, namespace, name string) (*DeploymentDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
 	}
 
-	data, err := json.MarshalIndent(serviceDetail, "", "  ")
+	kube, err := c.kube(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal service details: %w", err)
+		return nil, err
 	}
-
-	return string(data), nil
-}
-
-func (c *Client) getDeploymentDetails(ctx context.Context, namespace, name string) (string, error) {
-	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	deployment, err := kube.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+		return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
 	}
 
 	strategy := "RollingUpdate"
@@ -291,83 +429,98 @@ func (c *Client) getDeploymentDetails(ctx context.Context, namespace, name strin
 		strategy = "Recreate"
 	}
 
-	deploymentDetail := struct {
-		*DeploymentInfo
-		Selector   map[string]string `json:"selector"`
-		Conditions []string          `json:"conditions"`
-	}{
-		DeploymentInfo: &DeploymentInfo{
+	return &DeploymentDetails{
+		DeploymentInfo: DeploymentInfo{
 			Name:            deployment.Name,
 			Namespace:       deployment.Namespace,
-			TotalReplicas:   *deployment.Spec.Replicas,
+			TotalReplicas:   desiredReplicas(deployment.Spec.Replicas),
 			ReadyReplicas:   deployment.Status.ReadyReplicas,
 			UpdatedReplicas: deployment.Status.UpdatedReplicas,
 			Labels:          deployment.Labels,
 			CreatedAt:       deployment.CreationTimestamp.Time,
 			Strategy:        strategy,
 		},
-		Selector:   deployment.Spec.Selector.MatchLabels,
+		Selector:   matchLabels(deployment.Spec.Selector),
 		Conditions: getDeploymentConditions(deployment),
+		Events:     c.recentEventsOrNil(ctx, "Deployment", deployment.Namespace, deployment.Name),
+	}, nil
+}
+
+// GetConfigMap returns a configmap with its data; binaryData is summarized by size
+func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*ConfigMapInfo, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
 	}
 
-	data, err := json.MarshalIndent(deploymentDetail, "", "  ")
+	kube, err := c.kube(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal deployment details: %w", err)
+		return nil, err
 	}
-
-	return string(data), nil
-}
-
-func (c *Client) getConfigMapDetails(ctx context.Context, namespace, name string) (string, error) {
-	configmap, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	configmap, err := kube.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
 	}
 
-	configmapDetail := &ConfigMapInfo{
-		Name:      configmap.Name,
-		Namespace: configmap.Namespace,
-		Data:      configmap.Data,
-		Labels:    configmap.Labels,
-		CreatedAt: configmap.CreationTimestamp.Time,
+	return &ConfigMapInfo{
+		Name:       configmap.Name,
+		Namespace:  configmap.Namespace,
+		Data:       configmap.Data,
+		BinaryData: binaryDataSizes(configmap.BinaryData),
+		Labels:     configmap.Labels,
+		CreatedAt:  configmap.CreationTimestamp.Time,
+	}, nil
+}
+
+// GetNamespace returns a namespace with its status
+func (c *Client) GetNamespace(ctx context.Context, name string) (*NamespaceInfo, error) {
+	if err := c.scope.Check(name); err != nil {
+		return nil, err
 	}
 
-	data, err := json.MarshalIndent(configmapDetail, "", "  ")
+	kube, err := c.kube(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal configmap details: %w", err)
+		return nil, err
 	}
-
-	return string(data), nil
-}
-
-func (c *Client) getNamespaceDetails(ctx context.Context, name string) (string, error) {
-	namespace, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	namespace, err := kube.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get namespace %s: %w", name, err)
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
 	}
 
-	namespaceDetail := &NamespaceInfo{
+	return &NamespaceInfo{
 		Name:      namespace.Name,
 		Status:    string(namespace.Status.Phase),
 		Labels:    namespace.Labels,
 		CreatedAt: namespace.CreationTimestamp.Time,
-	}
+	}, nil
+}
 
-	data, err := json.MarshalIndent(namespaceDetail, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal namespace details: %w", err)
+// Helper functions
+// desiredReplicas reads spec.replicas, which the API server defaults to 1 when unset
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
 	}
+	return *replicas
+}
 
-	return string(data), nil
+// matchLabels returns the matchLabels of a workload selector, which may be nil
+func matchLabels(selector *metav1.LabelSelector) map[string]string {
+	if selector == nil {
+		return nil
+	}
+	return selector.MatchLabels
 }
 
-// Helper functions
-type ContainerInfo struct {
-	Name     string `json:"name"`
-	Image    string `json:"image"`
-	Ready    bool   `json:"ready"`
-	Restarts int32  `json:"restarts"`
-	State    string `json:"state"`
+// binaryDataSizes summarizes a configmap's binaryData by the size of each value
+func binaryDataSizes(binaryData map[string][]byte) map[string]int {
+	if len(binaryData) == 0 {
+		return nil
+	}
+	sizes := make(map[string]int, len(binaryData))
+	for key, value := range binaryData {
+		sizes[key] = len(value)
+	}
+	return sizes
 }
 
 func getContainerInfo(pod *corev1.Pod) []ContainerInfo {
@@ -407,22 +560,6 @@ func getTotalRestarts(pod *corev1.Pod) int32 {
 	return total
 }
 
-func buildConfig(configPath string) (*rest.Config, error) {
-	// try in-cluster config first
-	if config, err := rest.InClusterConfig(); err == nil {
-		return config, nil
-	}
-
-	// Fallback to kubeconfig file
-	if configPath == "" {
-		if home := homedir.HomeDir(); home != "" {
-			configPath = filepath.Join(home, ".kube", "config")
-		}
-	}
-
-	return clientcmd.BuildConfigFromFlags("", configPath)
-}
-
 func getPodRestartCount(pod *corev1.Pod) int32 {
 	var restarts int32
 	for _, status := range pod.Status.ContainerStatuses {
@@ -443,12 +580,20 @@ func getPodConditions(pod *corev1.Pod) []string {
 }
 
 func getDeploymentConditions(deployment *appsv1.Deployment) []string {
-	var conditions []string
-	for _, condition := range deployment.Status.Conditions {
-		if condition.Status == corev1.ConditionTrue {
-			conditions = append(conditions, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+	return trueConditions(deployment.Status.Conditions, func(condition appsv1.DeploymentCondition) (string, corev1.ConditionStatus, string) {
+		return string(condition.Type), condition.Status, condition.Message
+	})
+}
+
+// trueConditions renders the workload conditions that are currently true as
+// "<type>: <message>". fields returns the type, status and message of one condition,
+// since every workload kind has its own condition type.
+func trueConditions[C any](conditions []C, fields func(C) (string, corev1.ConditionStatus, string)) []string {
+	var rendered []string
+	for _, condition := range conditions {
+		if conditionType, status, message := fields(condition); status == corev1.ConditionTrue {
+			rendered = append(rendered, fmt.Sprintf("%s: %s", conditionType, message))
 		}
 	}
-
-	return conditions
+	return rendered
 }
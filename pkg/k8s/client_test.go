@@ -0,0 +1,997 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/audit"
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var created = metav1.NewTime(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC))
+
+func newTestClient(namespaces []string, objects ...runtime.Object) *Client {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewClientFromClientset(fake.NewSimpleClientset(objects...), "test", namespaces, logger)
+}
+
+func testNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: created},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+	}
+}
+
+func testPod(namespace, name string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": "web"}, CreationTimestamp: created},
+		Spec: corev1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []corev1.Container{{Name: "app", Image: "shop/web:1.2"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				Ready:        true,
+				RestartCount: restarts,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestListPodsInScope(t *testing.T) {
+	objects := []runtime.Object{
+		testNamespace("shop"), testNamespace("billing"), testNamespace("kube-system"),
+		testPod("shop", "web-0", 0),
+		testPod("billing", "invoice-0", 2),
+		testPod("kube-system", "coredns-0", 0),
+	}
+
+	tests := []struct {
+		name       string
+		scope      []string
+		namespace  string
+		want       []string
+		wantScoped bool
+	}{
+		{name: "all namespaces", scope: []string{"*"}, want: []string{"invoice-0", "coredns-0", "web-0"}},
+		{name: "scope limits cluster-wide lists", scope: []string{"shop", "billing"}, want: []string{"invoice-0", "web-0"}},
+		{name: "glob scope", scope: []string{"kube-*"}, want: []string{"coredns-0"}},
+		{name: "namespace in scope", scope: []string{"shop"}, namespace: "shop", want: []string{"web-0"}},
+		{name: "namespace out of scope", scope: []string{"shop"}, namespace: "kube-system", wantScoped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(tt.scope, objects...)
+			page, err := client.ListPods(context.Background(), tt.namespace, ListOptions{})
+			if tt.wantScoped {
+				if !errors.Is(err, ErrOutOfScope) {
+					t.Fatalf("ListPods() error = %v, want ErrOutOfScope", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListPods() error = %v", err)
+			}
+
+			names := map[string]bool{}
+			for _, pod := range page.Items {
+				names[pod.Name] = true
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("ListPods() returned %v, want %v", names, tt.want)
+			}
+			for _, name := range tt.want {
+				if !names[name] {
+					t.Errorf("ListPods() is missing %s, got %v", name, names)
+				}
+			}
+		})
+	}
+}
+
+func TestListNamespacesInScope(t *testing.T) {
+	client := newTestClient([]string{"shop", "bill*"}, testNamespace("shop"), testNamespace("billing"), testNamespace("kube-system"))
+
+	page, err := client.ListNamespaces(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("ListNamespaces() error = %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("ListNamespaces() returned %d namespaces, want 2: %+v", len(page.Items), page.Items)
+	}
+	for _, ns := range page.Items {
+		if ns.Name == "kube-system" {
+			t.Errorf("ListNamespaces() returned out-of-scope namespace %s", ns.Name)
+		}
+	}
+}
+
+func TestGetResource(t *testing.T) {
+	replicas := int32(3)
+	objects := []runtime.Object{
+		testNamespace("shop"),
+		testPod("shop", "web-0", 4),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: created},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.0.0.12",
+				Selector:  map[string]string{"app": "web"},
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: created},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2, UpdatedReplicas: 3},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "shop", CreationTimestamp: created},
+			Data:       map[string]string{"mode": "prod"},
+			BinaryData: map[string][]byte{"logo.png": make([]byte, 42)},
+		},
+	}
+	client := newTestClient([]string{"shop"}, objects...)
+	ctx := context.Background()
+
+	t.Run("pod", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypePod, Namespace: "shop", Name: "web-0"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		pod, ok := object.(*PodDetails)
+		if !ok {
+			t.Fatalf("GetResource() returned %T, want *PodDetails", object)
+		}
+		if pod.Restarts != 4 || pod.Node != "node-1" || pod.Phase != "Running" {
+			t.Errorf("unexpected pod info: %+v", pod.PodInfo)
+		}
+		if len(pod.Containers) != 1 || pod.Containers[0].Restarts != 4 || pod.Containers[0].State != "Running" || !pod.Containers[0].Ready {
+			t.Errorf("unexpected containers: %+v", pod.Containers)
+		}
+		if len(pod.Conditions) != 1 || pod.Conditions[0] != "Ready" {
+			t.Errorf("unexpected conditions: %v", pod.Conditions)
+		}
+	})
+
+	t.Run("service", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeService, Namespace: "shop", Name: "web"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		service := object.(*ServiceDetails)
+		if len(service.Ports) != 1 || service.Ports[0].TargetPort != "http" {
+			t.Errorf("unexpected ports: %+v", service.Ports)
+		}
+		if service.MatchingPods == nil || *service.MatchingPods != 1 {
+			t.Errorf("MatchingPods = %v, want 1", service.MatchingPods)
+		}
+	})
+
+	t.Run("deployment", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeDeployment, Namespace: "shop", Name: "web"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		deployment := object.(*DeploymentDetails)
+		if deployment.TotalReplicas != 3 || deployment.ReadyReplicas != 2 || deployment.Strategy != "Recreate" {
+			t.Errorf("unexpected deployment info: %+v", deployment.DeploymentInfo)
+		}
+		if deployment.Selector["app"] != "web" {
+			t.Errorf("Selector = %v, want app=web", deployment.Selector)
+		}
+	})
+
+	t.Run("configmap", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeConfigMap, Namespace: "shop", Name: "settings"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		configmap := object.(*ConfigMapInfo)
+		if configmap.Data["mode"] != "prod" || configmap.BinaryData["logo.png"] != 42 {
+			t.Errorf("unexpected configmap: %+v", configmap)
+		}
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeNamespace, Name: "shop"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		if namespace := object.(*NamespaceInfo); namespace.Status != "Active" {
+			t.Errorf("Status = %q, want Active", namespace.Status)
+		}
+	})
+
+	t.Run("out of scope", func(t *testing.T) {
+		_, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeNamespace, Name: "kube-system"})
+		if !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("GetResource() error = %v, want ErrOutOfScope", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypePod, Namespace: "shop", Name: "missing"}); err == nil {
+			t.Error("GetResource() of a missing pod succeeded")
+		}
+	})
+}
+
+func TestGetServiceWithoutEndpointsAccess(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: created},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Selector: map[string]string{"app": "web"}},
+	}
+	client := newTestClient(nil, testNamespace("shop"), service, testPod("shop", "web-0", 0))
+	forbidden := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: action.GetResource().Resource}, "", errors.New("no RBAC"))
+	}
+
+	fakeClientset := client.clientset.(*fake.Clientset)
+	fakeClientset.PrependReactor("list", "endpointslices", forbidden)
+	details, err := client.GetService(context.Background(), "shop", "web")
+	if err != nil {
+		t.Fatalf("GetService() error = %v, want the service without endpoints", err)
+	}
+	if details.Endpoints != nil {
+		t.Errorf("Endpoints = %v, want nil when they cannot be read", details.Endpoints)
+	}
+	if details.MatchingPods == nil || *details.MatchingPods != 1 {
+		t.Errorf("MatchingPods = %v, want 1", details.MatchingPods)
+	}
+
+	fakeClientset.PrependReactor("list", "pods", forbidden)
+	details, err = client.GetService(context.Background(), "shop", "web")
+	if err != nil {
+		t.Fatalf("GetService() error = %v, want the service without a pod count", err)
+	}
+	if details.MatchingPods != nil {
+		t.Errorf("MatchingPods = %d, want nil when pods cannot be read", *details.MatchingPods)
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "db",
+			Namespace:   "shop",
+			Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"aHVudGVyMg=="}}`},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("hunter2"), "user": []byte("app")},
+	}
+	client := newTestClient([]string{"shop"}, secret)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		reveal       []string
+		wantRevealed map[string]string
+		wantErr      bool
+	}{
+		{name: "redacted by default"},
+		{name: "reveal one key", reveal: []string{"user"}, wantRevealed: map[string]string{"user": "app"}},
+		{name: "unknown key", reveal: []string{"token"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := client.GetSecret(ctx, "shop", "db", tt.reveal)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("GetSecret() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecret() error = %v", err)
+			}
+
+			if info.Type != "Opaque" || len(info.Keys) != 2 || info.Keys[0].Key != "password" || info.Keys[1].Key != "user" {
+				t.Fatalf("unexpected secret: %+v", info)
+			}
+			password := info.Keys[0]
+			if password.Size != 7 || password.SHA256 != "f52fbd32b2b3b86ff88ef6c490628285f482af15ddcb29541f94bcf526a3f6c7" {
+				t.Errorf("unexpected password key info: %+v", password)
+			}
+			for _, key := range info.Keys {
+				want, revealed := tt.wantRevealed[key.Key]
+				if key.Revealed != revealed || key.Value != want {
+					t.Errorf("key %s: revealed=%v value=%q, want revealed=%v value=%q", key.Key, key.Revealed, key.Value, revealed, want)
+				}
+			}
+		})
+	}
+
+	t.Run("list never reveals", func(t *testing.T) {
+		page, err := client.ListSecrets(ctx, "shop", ListOptions{})
+		if err != nil {
+			t.Fatalf("ListSecrets() error = %v", err)
+		}
+		for _, key := range page.Items[0].Keys {
+			if key.Revealed || key.Value != "" {
+				t.Errorf("ListSecrets() revealed key %s", key.Key)
+			}
+		}
+	})
+}
+
+func TestFakeClientCannotImpersonate(t *testing.T) {
+	client := newTestClient([]string{"shop"}, testNamespace("shop"))
+	ctx := WithImpersonation(context.Background(), "alice", []string{"dev"})
+
+	if _, err := client.ListPods(ctx, "shop", ListOptions{}); err == nil {
+		t.Fatal("ListPods() as an impersonated caller succeeded on a client without a REST config")
+	}
+}
+
+// TestAuditedAPICallsNameTheCluster checks that API calls are audited with the name the
+// cluster is registered under rather than its kubeconfig context
+func TestAuditedAPICallsNameTheCluster(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"shop"}}`)
+	}))
+	defer apiServer.Close()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: %s
+contexts:
+- name: admin@prod
+  context:
+    cluster: prod
+    user: admin
+users:
+- name: admin
+  user:
+    token: admin-token
+`, apiServer.URL)), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	tests := []struct {
+		cluster string
+		want    string
+	}{
+		{cluster: "prod", want: "prod"},
+		{cluster: "", want: "admin@prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			client, err := NewClient(tt.cluster, kubeconfig, "admin@prod", nil, logger)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			var records bytes.Buffer
+			ctx, _ := audit.NewLogger(nopCloser{&records}).Start(context.Background(), "alice", nil)
+			if _, err := client.GetNamespace(ctx, "shop"); err != nil {
+				t.Fatalf("GetNamespace() error = %v", err)
+			}
+
+			var event audit.Event
+			if err := json.Unmarshal(records.Bytes(), &event); err != nil {
+				t.Fatalf("failed to decode audit record %q: %v", records.String(), err)
+			}
+			if event.Cluster != tt.want || event.Verb != "get" || event.Resource != "namespaces" || event.Name != "shop" {
+				t.Errorf("audit record = %+v, want a get of namespace shop in cluster %s", event, tt.want)
+			}
+		})
+	}
+}
+
+// nopCloser turns a writer into the io.WriteCloser an audit logger writes to
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestImpersonatedClientsetCache(t *testing.T) {
+	client := newTestClient(nil)
+	client.config = &rest.Config{Host: "https://127.0.0.1:6443"}
+	kubeAs := func(user string) kubernetes.Interface {
+		t.Helper()
+		kube, err := client.kube(WithImpersonation(context.Background(), user, nil))
+		if err != nil {
+			t.Fatalf("kube() as %s error = %v", user, err)
+		}
+		return kube
+	}
+
+	alice := kubeAs("alice")
+	for i := 0; i < maxImpersonatedClients; i++ {
+		kubeAs(fmt.Sprintf("user-%d", i))
+		// alice calls in between, so that clientset stays the most recently used
+		if kubeAs("alice") != alice {
+			t.Fatalf("alice's clientset was evicted after %d other callers", i+1)
+		}
+	}
+	if got := client.impersonated.Len(); got != maxImpersonatedClients {
+		t.Errorf("cache holds %d clientsets, want %d", got, maxImpersonatedClients)
+	}
+}
+
+func TestGetWorkloads(t *testing.T) {
+	replicas := int32(3)
+	partition := int32(2)
+	storageClass := "fast"
+	isController := true
+	objects := []runtime.Object{
+		testNamespace("shop"),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", CreationTimestamp: created},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:    &replicas,
+				ServiceName: "db-headless",
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+				},
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: &storageClass,
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				}},
+			},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentReplicas: 2, UpdatedReplicas: 1, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+		},
+		func() *corev1.Pod {
+			pod := testPod("shop", "db-0", 0)
+			pod.Labels = map[string]string{"app": "db"}
+			return pod
+		}(),
+		func() *corev1.Pod {
+			pod := testPod("shop", "db-1", 0)
+			pod.Labels = map[string]string{"app": "db"}
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.Conditions = nil
+			return pod
+		}(),
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop", CreationTimestamp: created},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}}},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, CurrentNumberScheduled: 2, NumberMisscheduled: 1, NumberReady: 2, NumberAvailable: 2},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "web-7d9f",
+				Namespace:         "shop",
+				CreationTimestamp: created,
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": "4"},
+				OwnerReferences:   []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: &isController}},
+			},
+			Spec:   appsv1.ReplicaSetSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			Status: appsv1.ReplicaSetStatus{ReadyReplicas: 3, AvailableReplicas: 3},
+		},
+	}
+	client := newTestClient([]string{"shop"}, objects...)
+	ctx := context.Background()
+
+	t.Run("statefulset", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeStatefulSet, Namespace: "shop", Name: "db"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		statefulset, ok := object.(*StatefulSetDetails)
+		if !ok {
+			t.Fatalf("GetResource() returned %T, want *StatefulSetDetails", object)
+		}
+		if statefulset.TotalReplicas != 3 || statefulset.Partition != 2 || statefulset.ServiceName != "db-headless" || statefulset.PodManagementPolicy != "OrderedReady" {
+			t.Errorf("unexpected statefulset info: %+v", statefulset)
+		}
+
+		want := []StatefulSetOrdinal{
+			{Ordinal: 0, Pod: "db-0", Phase: "Running", Ready: true},
+			{Ordinal: 1, Pod: "db-1", Phase: "Pending"},
+			{Ordinal: 2, Pod: "db-2"},
+		}
+		if len(statefulset.Ordinals) != len(want) {
+			t.Fatalf("Ordinals = %+v, want %+v", statefulset.Ordinals, want)
+		}
+		for i := range want {
+			if statefulset.Ordinals[i] != want[i] {
+				t.Errorf("Ordinals[%d] = %+v, want %+v", i, statefulset.Ordinals[i], want[i])
+			}
+		}
+
+		if len(statefulset.VolumeClaimTemplates) != 1 {
+			t.Fatalf("VolumeClaimTemplates = %+v, want one template", statefulset.VolumeClaimTemplates)
+		}
+		template := statefulset.VolumeClaimTemplates[0]
+		if template.Name != "data" || template.Storage != "10Gi" || template.StorageClass != "fast" || len(template.AccessModes) != 1 || template.AccessModes[0] != "ReadWriteOnce" {
+			t.Errorf("unexpected volume claim template: %+v", template)
+		}
+	})
+
+	t.Run("daemonset", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeDaemonSet, Namespace: "shop", Name: "agent"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		daemonset := object.(*DaemonSetDetails)
+		if daemonset.DesiredNumberScheduled != 3 || daemonset.NumberMisscheduled != 1 || daemonset.UpdateStrategy != "RollingUpdate" || daemonset.NodeSelector["kubernetes.io/os"] != "linux" {
+			t.Errorf("unexpected daemonset info: %+v", daemonset.DaemonSetInfo)
+		}
+	})
+
+	t.Run("replicaset", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeReplicaSet, Namespace: "shop", Name: "web-7d9f"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		replicaset := object.(*ReplicaSetDetails)
+		if replicaset.Owner != "web" || replicaset.Revision != "4" || replicaset.TotalReplicas != 3 {
+			t.Errorf("unexpected replicaset info: %+v", replicaset.ReplicaSetInfo)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		page, err := client.ListStatefulSets(ctx, "", ListOptions{})
+		if err != nil {
+			t.Fatalf("ListStatefulSets() error = %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].Name != "db" || page.Items[0].UpdateStrategy != "RollingUpdate" {
+			t.Errorf("ListStatefulSets() = %+v", page.Items)
+		}
+	})
+
+	t.Run("statefulset without pod access", func(t *testing.T) {
+		client := newTestClient([]string{"shop"}, objects...)
+		client.clientset.(*fake.Clientset).PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))
+		})
+		statefulset, err := client.GetStatefulSet(ctx, "shop", "db")
+		if err != nil {
+			t.Fatalf("GetStatefulSet() error = %v, want the statefulset without ordinals", err)
+		}
+		if statefulset.Ordinals != nil {
+			t.Errorf("Ordinals = %+v, want nil when pods cannot be read", statefulset.Ordinals)
+		}
+	})
+}
+
+func TestGetBatch(t *testing.T) {
+	backoffLimit := int32(2)
+	isController := true
+	suspend := true
+	timeZone := "Europe/Berlin"
+	spawnedJob := func(owner apitypes.UID, name string, age time.Duration, condition batchv1.JobConditionType) *batchv1.Job {
+		cronjob, _, _ := strings.Cut(name, "-")
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "shop",
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+				OwnerReferences:   []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "CronJob", Name: cronjob, UID: owner, Controller: &isController}},
+			},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}},
+		}
+		if cronjob == "report" {
+			job.Labels = map[string]string{"app": "report"}
+		}
+		return job
+	}
+	objects := []runtime.Object{
+		testNamespace("shop"),
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "shop", CreationTimestamp: created},
+			Spec:       batchv1.JobSpec{BackoffLimit: &backoffLimit},
+			Status: batchv1.JobStatus{
+				Failed: 3,
+				Conditions: []batchv1.JobCondition{{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "BackoffLimitExceeded",
+					Message: "Job has reached the specified backoff limit",
+				}},
+			},
+		},
+		func() *corev1.Pod {
+			pod := testPod("shop", "migrate-x7k2p", 0)
+			pod.Labels = map[string]string{"job-name": "migrate"}
+			pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+			}
+			pod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"},
+			}
+			return pod
+		}(),
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "shop", UID: "report-uid", CreationTimestamp: created},
+			Spec: batchv1.CronJobSpec{
+				Schedule:    "0 * * * *",
+				JobTemplate: batchv1.JobTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "report"}}},
+			},
+		},
+		spawnedJob("report-uid", "report-3", time.Hour, batchv1.JobFailed),
+		spawnedJob("report-uid", "report-2", 2*time.Hour, batchv1.JobFailed),
+		spawnedJob("report-uid", "report-1", 3*time.Hour, batchv1.JobComplete),
+		spawnedJob("report-uid", "report-0", 4*time.Hour, batchv1.JobFailed),
+		// Left behind by an earlier cronjob of the same name
+		spawnedJob("deleted-report-uid", "report-old", 5*time.Hour, batchv1.JobFailed),
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "shop", UID: "cleanup-uid", CreationTimestamp: created},
+			Spec:       batchv1.CronJobSpec{Schedule: "30 2 * * *", TimeZone: &timeZone, Suspend: &suspend},
+		},
+		spawnedJob("cleanup-uid", "cleanup-1", time.Hour, batchv1.JobFailed),
+		spawnedJob("cleanup-uid", "cleanup-0", 25*time.Hour, batchv1.JobComplete),
+	}
+	client := newTestClient([]string{"shop"}, objects...)
+	client.now = func() time.Time { return created.Time }
+	ctx := context.Background()
+
+	t.Run("failed job", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeJob, Namespace: "shop", Name: "migrate"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		job, ok := object.(*JobDetails)
+		if !ok {
+			t.Fatalf("GetResource() returned %T, want *JobDetails", object)
+		}
+		if job.Status != "Failed" || job.BackoffLimit != 2 || job.Completions != 1 || job.FailureReason != "BackoffLimitExceeded" {
+			t.Errorf("unexpected job info: %+v", job)
+		}
+		want := []JobPodFailure{{Pod: "migrate-x7k2p", Container: "app", Reason: "OOMKilled", ExitCode: 137}}
+		if len(job.PodFailures) != len(want) || job.PodFailures[0] != want[0] {
+			t.Errorf("PodFailures = %+v, want %+v", job.PodFailures, want)
+		}
+	})
+
+	t.Run("failed job without pod access", func(t *testing.T) {
+		client := newTestClient([]string{"shop"}, objects...)
+		client.clientset.(*fake.Clientset).PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))
+		})
+		job, err := client.GetJob(ctx, "shop", "migrate")
+		if err != nil {
+			t.Fatalf("GetJob() error = %v, want the job without its failed containers", err)
+		}
+		if job.Status != "Failed" || job.PodFailures != nil {
+			t.Errorf("Status = %s, PodFailures = %+v, want a failed job without failed containers", job.Status, job.PodFailures)
+		}
+	})
+
+	t.Run("cronjob history", func(t *testing.T) {
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeCronJob, Namespace: "shop", Name: "report"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		cronjob, ok := object.(*CronJobDetails)
+		if !ok {
+			t.Fatalf("GetResource() returned %T, want *CronJobDetails", object)
+		}
+		if cronjob.ConsecutiveFailures != 2 || cronjob.ConcurrencyPolicy != "Allow" || cronjob.SuccessfulJobsHistoryLimit != 3 || cronjob.FailedJobsHistoryLimit != 1 {
+			t.Errorf("unexpected cronjob details: %+v", cronjob)
+		}
+		var names []string
+		for _, job := range cronjob.RecentJobs {
+			names = append(names, job.Name)
+		}
+		if len(names) != 4 || names[0] != "report-3" || names[3] != "report-0" {
+			t.Errorf("RecentJobs = %v, want newest first", names)
+		}
+		if next := created.Add(time.Hour); cronjob.NextScheduleTime == nil || !cronjob.NextScheduleTime.Equal(next) {
+			t.Errorf("NextScheduleTime = %v, want %v", cronjob.NextScheduleTime, next)
+		}
+	})
+
+	t.Run("cronjob without template labels", func(t *testing.T) {
+		cronjob, err := client.GetCronJob(ctx, "shop", "cleanup")
+		if err != nil {
+			t.Fatalf("GetCronJob() error = %v", err)
+		}
+		var names []string
+		for _, job := range cronjob.RecentJobs {
+			names = append(names, job.Name)
+		}
+		if strings.Join(names, ",") != "cleanup-1,cleanup-0" || cronjob.ConsecutiveFailures != 1 {
+			t.Errorf("RecentJobs = %v, ConsecutiveFailures = %d, want the jobs the cronjob controls", names, cronjob.ConsecutiveFailures)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		page, err := client.ListCronJobs(ctx, "shop", ListOptions{})
+		if err != nil {
+			t.Fatalf("ListCronJobs() error = %v", err)
+		}
+		for _, cronjob := range page.Items {
+			if cronjob.Name == "cleanup" && (!cronjob.Suspended || cronjob.NextScheduleTime != nil || cronjob.TimeZone != timeZone) {
+				t.Errorf("unexpected suspended cronjob: %+v", cronjob)
+			}
+		}
+	})
+}
+
+func TestNextScheduleTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+		want     time.Time
+		wantErr  bool
+	}{
+		{name: "every five minutes", schedule: "*/5 * * * *", want: now.Add(5 * time.Minute)},
+		{name: "descriptor", schedule: "@daily", want: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "time zone", schedule: "30 2 * * *", timeZone: "Europe/Berlin", want: time.Date(2025, 6, 2, 0, 30, 0, 0, time.UTC)},
+		{name: "invalid", schedule: "every day", wantErr: true},
+		{name: "unknown time zone", schedule: "0 * * * *", timeZone: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextScheduleTime(tt.schedule, tt.timeZone, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextScheduleTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("nextScheduleTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("schedules without a time zone run in UTC", func(t *testing.T) {
+		cronjob := &batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "@daily"}}
+		info := newCronJobInfo(cronjob, now.In(time.FixedZone("UTC+5", 5*60*60)))
+		if want := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC); info.NextScheduleTime == nil || !info.NextScheduleTime.Equal(want) {
+			t.Errorf("NextScheduleTime = %v, want %v", info.NextScheduleTime, want)
+		}
+	})
+}
+
+func TestGetNode(t *testing.T) {
+	withRequests := func(pod *corev1.Pod, cpu, memory string) *corev1.Pod {
+		pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+		}
+		return pod
+	}
+	always := corev1.ContainerRestartPolicyAlways
+	objects := []runtime.Object{
+		testNamespace("shop"),
+		testNamespace("kube-system"),
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "node-1",
+				CreationTimestamp: created,
+				Labels:            map[string]string{"node-role.kubernetes.io/control-plane": "", "kubernetes.io/os": "linux"},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+				Taints:        []corev1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}, {Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoExecute}},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"},
+					{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+					{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+				},
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: "node-1"}, {Type: corev1.NodeInternalIP, Address: "10.0.0.5"}},
+				NodeInfo:  corev1.NodeSystemInfo{KubeletVersion: "v1.31.2", OperatingSystem: "linux", Architecture: "arm64", OSImage: "Ubuntu 24.04"},
+			},
+		},
+		withRequests(testPod("shop", "web-0", 0), "500m", "256Mi"),
+		func() *corev1.Pod {
+			// A sidecar counts towards the pod, a larger regular init container wins over both
+			pod := withRequests(testPod("shop", "web-1", 0), "500m", "256Mi")
+			pod.Spec.InitContainers = []corev1.Container{
+				{Name: "proxy", RestartPolicy: &always, Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}},
+				{Name: "migrate", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}}},
+			}
+			return pod
+		}(),
+		withRequests(testPod("kube-system", "dns", 0), "250m", "128Mi"),
+		func() *corev1.Pod {
+			pod := withRequests(testPod("shop", "report", 0), "1", "1Gi")
+			pod.Status.Phase = corev1.PodSucceeded
+			return pod
+		}(),
+		func() *corev1.Pod {
+			pod := withRequests(testPod("shop", "api-0", 0), "1", "1Gi")
+			pod.Spec.NodeName = "node-2"
+			return pod
+		}(),
+	}
+	ctx := context.Background()
+
+	t.Run("cluster scope", func(t *testing.T) {
+		client := newTestClient(nil, objects...)
+		object, err := client.GetResource(ctx, &types.ResourceIdentifier{Type: types.ResourceTypeNode, Name: "node-1"})
+		if err != nil {
+			t.Fatalf("GetResource() error = %v", err)
+		}
+		node, ok := object.(*NodeDetails)
+		if !ok {
+			t.Fatalf("GetResource() returned %T, want *NodeDetails", object)
+		}
+
+		if node.Status != "Ready" || !node.Unschedulable || node.InternalIP != "10.0.0.5" || node.Architecture != "arm64" || node.Capacity.Memory != "16Gi" || node.Allocatable.CPU != "2" {
+			t.Errorf("unexpected node info: %+v", node.NodeInfo)
+		}
+		if len(node.Roles) != 1 || node.Roles[0] != "control-plane" {
+			t.Errorf("Roles = %v, want [control-plane]", node.Roles)
+		}
+		if len(node.Pressures) != 1 || node.Pressures[0] != "MemoryPressure" {
+			t.Errorf("Pressures = %v, want [MemoryPressure]", node.Pressures)
+		}
+		if len(node.Taints) != 2 || node.Taints[1] != "dedicated=db:NoExecute" {
+			t.Errorf("Taints = %v", node.Taints)
+		}
+		if node.PodsInScopeOnly {
+			t.Error("PodsInScopeOnly = true for an unrestricted client")
+		}
+		if node.Events == nil {
+			t.Error("Events = nil, want the node's events for an unrestricted client")
+		}
+
+		var pods []string
+		for _, pod := range node.Pods {
+			pods = append(pods, pod.Namespace+"/"+pod.Name)
+		}
+		if strings.Join(pods, ",") != "kube-system/dns,shop/web-0,shop/web-1" {
+			t.Errorf("Pods = %v, want the running pods on node-1", pods)
+		}
+		if web1 := node.Pods[2]; web1.CPURequests != "600m" || web1.MemoryRequests != "512Mi" {
+			t.Errorf("web-1 requests = %s cpu, %s memory, want 600m and 512Mi", web1.CPURequests, web1.MemoryRequests)
+		}
+
+		want := []NodeAllocation{
+			{Resource: "cpu", Requests: "1350m", Limits: "0", Allocatable: "2", RequestsPercent: 67, LimitsPercent: 0},
+			{Resource: "memory", Requests: "896Mi", Limits: "640Mi", Allocatable: "1Gi", RequestsPercent: 87, LimitsPercent: 62},
+		}
+		if len(node.Allocated) != len(want) {
+			t.Fatalf("Allocated = %+v, want %+v", node.Allocated, want)
+		}
+		for i := range want {
+			if node.Allocated[i] != want[i] {
+				t.Errorf("Allocated[%d] = %+v, want %+v", i, node.Allocated[i], want[i])
+			}
+		}
+	})
+
+	t.Run("namespace scope", func(t *testing.T) {
+		client := newTestClient([]string{"kube-system"}, objects...)
+		node, err := client.GetNode(ctx, "node-1")
+		if err != nil {
+			t.Fatalf("GetNode() error = %v", err)
+		}
+		if !node.PodsInScopeOnly || len(node.Pods) != 1 || node.Pods[0].Name != "dns" {
+			t.Errorf("GetNode() counted pods outside the scope: %+v", node.Pods)
+		}
+		if node.Events != nil {
+			t.Errorf("Events = %+v, want none since node events are listed across all namespaces", node.Events)
+		}
+		if err := client.checkScope(&types.ResourceIdentifier{Type: types.ResourceTypeNode, Name: "node-1"}); err != nil {
+			t.Errorf("checkScope() error = %v, nodes belong to no namespace", err)
+		}
+	})
+
+	t.Run("without pod access", func(t *testing.T) {
+		client := newTestClient(nil, objects...)
+		client.clientset.(*fake.Clientset).PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))
+		})
+		node, err := client.GetNode(ctx, "node-1")
+		if err != nil {
+			t.Fatalf("GetNode() error = %v, want the node without pod allocation", err)
+		}
+		if node.Status != "Ready" || node.Pods != nil || node.Allocated != nil {
+			t.Errorf("GetNode() = status %s, pods %+v, allocated %+v, want a ready node without pods", node.Status, node.Pods, node.Allocated)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		page, err := newTestClient([]string{"kube-system"}, objects...).ListNodes(ctx, ListOptions{})
+		if err != nil {
+			t.Fatalf("ListNodes() error = %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].Name != "node-1" || page.Items[0].KubeletVersion != "v1.31.2" {
+			t.Errorf("ListNodes() = %+v", page.Items)
+		}
+	})
+}
+
+func TestNewestLines(t *testing.T) {
+	huge := strings.Repeat("x", maxLogLineBytes+10)
+	tests := []struct {
+		name        string
+		input       string
+		grep        string
+		maxBytes    int
+		want        []string
+		wantDropped bool
+		wantCut     bool
+	}{
+		{name: "fits", input: "a\nbb\nccc\n", maxBytes: 100, want: []string{"a", "bb", "ccc"}},
+		{name: "newest lines win", input: "a\nbb\nccc", maxBytes: 7, want: []string{"bb", "ccc"}, wantDropped: true},
+		{name: "grep", input: "info ok\nerror boom\ninfo ok\n", grep: "error", maxBytes: 100, want: []string{"error boom"}},
+		{name: "only line longer than the budget", input: "error " + strings.Repeat("y", 20) + "\n", maxBytes: 10, want: []string{"error yyyy"}, wantCut: true},
+		{name: "newest line longer than the budget", input: "a\nerror " + strings.Repeat("y", 20) + "\n", maxBytes: 10, want: []string{"error yyyy"}, wantDropped: true, wantCut: true},
+		{name: "cut line that was dropped", input: strings.Repeat("y", 20) + "\nok\n", maxBytes: 10, want: []string{"ok"}, wantDropped: true},
+		{name: "cut line filtered out", input: huge + "\nerror\n", grep: "error", maxBytes: 100, want: []string{"error"}},
+		{name: "line longer than the line limit", input: "before\n" + huge + "\nafter\n", maxBytes: DefaultLogMaxBytes, want: []string{"after"}, wantDropped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter *regexp.Regexp
+			if tt.grep != "" {
+				filter = regexp.MustCompile(tt.grep)
+			}
+			got, dropped, cut, err := newestLines(strings.NewReader(tt.input), filter, tt.maxBytes)
+			if err != nil {
+				t.Fatalf("newestLines() error = %v", err)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") || dropped != tt.wantDropped || cut != tt.wantCut {
+				t.Errorf("newestLines() = %q, dropped %v, cut %v, want %q, dropped %v, cut %v", got, dropped, cut, tt.want, tt.wantDropped, tt.wantCut)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"onlylight/k8s-mcp-server/pkg/types"
+)
+
+// ClusterInfo describes a cluster in the registry
+type ClusterInfo struct {
+	Name    string `json:"name"`
+	Context string `json:"context"`
+	Default bool   `json:"default"`
+}
+
+// ClusterRegistry holds one client per cluster, keyed by cluster name. Requests that
+// don't name a cluster go to the default cluster.
+type ClusterRegistry struct {
+	mu          sync.RWMutex
+	clients     map[string]ClusterClient
+	defaultName string
+}
+
+// NewClusterRegistry creates an empty registry
+func NewClusterRegistry() *ClusterRegistry {
+	return &ClusterRegistry{
+		clients: make(map[string]ClusterClient),
+	}
+}
+
+// Add registers a client under name. The first cluster added becomes the default.
+func (r *ClusterRegistry) Add(name string, client ClusterClient) error {
+	if err := validateClusterName(name); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[name]; exists {
+		return fmt.Errorf("cluster %q is already registered", name)
+	}
+	r.clients[name] = client
+	if r.defaultName == "" {
+		r.defaultName = name
+	}
+	return nil
+}
+
+// Get returns the client for a cluster, or the default client when name is empty
+func (r *ClusterRegistry) Get(name string) (ClusterClient, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		name = r.defaultName
+	}
+	client, ok := r.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q, available clusters: %s", name, strings.Join(r.namesLocked(), ", "))
+	}
+	return client, nil
+}
+
+// Default returns the client of the default cluster
+func (r *ClusterRegistry) Default() ClusterClient {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clients[r.defaultName]
+}
+
+// DefaultName returns the name of the default cluster
+func (r *ClusterRegistry) DefaultName() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.defaultName
+}
+
+// Names returns the registered cluster names, sorted
+func (r *ClusterRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+// Clusters describes every registered cluster
+func (r *ClusterRegistry) Clusters() []ClusterInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var clusters []ClusterInfo
+	for _, name := range r.namesLocked() {
+		clusters = append(clusters, ClusterInfo{
+			Name:    name,
+			Context: r.clients[name].ContextName(),
+			Default: name == r.defaultName,
+		})
+	}
+	return clusters
+}
+
+// SetDefault makes a registered cluster the default
+func (r *ClusterRegistry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clients[name]; !ok {
+		return fmt.Errorf("unknown cluster %q, available clusters: %s", name, strings.Join(r.namesLocked(), ", "))
+	}
+	r.defaultName = name
+	return nil
+}
+
+func (r *ClusterRegistry) namesLocked() []string {
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateClusterName rejects names that would make k8s:// URIs ambiguous
+func validateClusterName(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid cluster name %q", name)
+	}
+	if types.IsResourceType(name) {
+		return fmt.Errorf("invalid cluster name %q: it is also a resource type", name)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+)
+
+// getServiceEndpoints reads the discovery.k8s.io/v1 EndpointSlices that back a service
+func (c *Client) getServiceEndpoints(ctx context.Context, namespace, name string) ([]EndpointInfo, error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices, err := kube.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: name}.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpointslices for service %s/%s: %w", namespace, name, err)
+	}
+
+	endpoints := []EndpointInfo{}
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			info := EndpointInfo{
+				// A nil ready condition means unknown, which consumers must treat as ready
+				Ready: endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready,
+			}
+			if endpoint.NodeName != nil {
+				info.Node = *endpoint.NodeName
+			}
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				info.TargetPod = endpoint.TargetRef.Name
+			}
+
+			for _, address := range endpoint.Addresses {
+				info.Address = address
+				endpoints = append(endpoints, info)
+			}
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Ready != endpoints[j].Ready {
+			return endpoints[i].Ready
+		}
+		return endpoints[i].Address < endpoints[j].Address
+	})
+
+	return endpoints, nil
+}
+
+// partialObjectMetadataList asks the API server for object metadata only
+const partialObjectMetadataList = "application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1"
+
+// countSelectedPods returns how many pods in the namespace match a service selector.
+// Only pod metadata is fetched; clientsets without a REST client, such as the fake one
+// used in tests, fall back to a regular list.
+func (c *Client) countSelectedPods(ctx context.Context, namespace string, selector map[string]string) (int, error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return 0, err
+	}
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(selector).String()}
+
+	if restClient, ok := kube.CoreV1().RESTClient().(*rest.RESTClient); ok && restClient != nil {
+		data, err := restClient.Get().
+			Namespace(namespace).
+			Resource("pods").
+			VersionedParams(&listOptions, metav1.ParameterCodec).
+			SetHeader("Accept", partialObjectMetadataList).
+			DoRaw(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list pods for selector in namespace %s: %w", namespace, err)
+		}
+		var pods metav1.PartialObjectMetadataList
+		if err := json.Unmarshal(data, &pods); err != nil {
+			return 0, fmt.Errorf("failed to decode pods for selector in namespace %s: %w", namespace, err)
+		}
+		return len(pods.Items), nil
+	}
+
+	pods, err := kube.CoreV1().Pods(namespace).List(ctx, listOptions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pods for selector in namespace %s: %w", namespace, err)
+	}
+	return len(pods.Items), nil
+}
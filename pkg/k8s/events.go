@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxRecentEvents bounds how many events are attached to a resource detail
+const maxRecentEvents = 10
+
+// getRecentEvents returns the events whose involvedObject is the given object, newest
+// first. Repeated events with the same type, reason and message are merged into one
+// entry whose count is the sum of the originals.
+func (c *Client) getRecentEvents(ctx context.Context, kind, namespace, name string) ([]EventInfo, error) {
+	selector := fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	}
+	if namespace != "" {
+		selector["involvedObject.namespace"] = namespace
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := kube.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: selector.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s %s/%s: %w", kind, namespace, name, err)
+	}
+
+	type eventKey struct {
+		eventType string
+		reason    string
+		message   string
+	}
+
+	merged := make(map[eventKey]*EventInfo)
+	var order []eventKey
+	for _, event := range events.Items {
+		key := eventKey{event.Type, event.Reason, event.Message}
+		first, last := eventTimes(&event)
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+
+		existing, ok := merged[key]
+		if !ok {
+			merged[key] = &EventInfo{
+				Type:      event.Type,
+				Reason:    event.Reason,
+				Message:   event.Message,
+				Count:     count,
+				FirstSeen: first,
+				LastSeen:  last,
+			}
+			order = append(order, key)
+			continue
+		}
+
+		existing.Count += count
+		if first.Before(existing.FirstSeen) {
+			existing.FirstSeen = first
+		}
+		if last.After(existing.LastSeen) {
+			existing.LastSeen = last
+		}
+	}
+
+	eventInfos := make([]EventInfo, 0, len(order))
+	for _, key := range order {
+		eventInfos = append(eventInfos, *merged[key])
+	}
+
+	sort.SliceStable(eventInfos, func(i, j int) bool {
+		return eventInfos[i].LastSeen.After(eventInfos[j].LastSeen)
+	})
+
+	if len(eventInfos) > maxRecentEvents {
+		eventInfos = eventInfos[:maxRecentEvents]
+	}
+
+	return eventInfos, nil
+}
+
+// recentEventsOrNil fetches events for a detail view. Missing events should not hide the
+// object itself, so failures are logged and reported as no events.
+func (c *Client) recentEventsOrNil(ctx context.Context, kind, namespace, name string) []EventInfo {
+	events, err := c.getRecentEvents(ctx, kind, namespace, name)
+	if err != nil {
+		c.logger.WithContext(ctx).Warnf("Failed to get events: %v", err)
+		return nil
+	}
+	return events
+}
+
+// eventTimes returns when an event was first and last observed, falling back to the
+// newer events.k8s.io fields for events that do not set the legacy timestamps
+func eventTimes(event *corev1.Event) (time.Time, time.Time) {
+	last := event.LastTimestamp.Time
+	if last.IsZero() {
+		last = event.EventTime.Time
+	}
+	if last.IsZero() {
+		last = event.CreationTimestamp.Time
+	}
+
+	first := event.FirstTimestamp.Time
+	if first.IsZero() {
+		first = last
+	}
+
+	return first, last
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// maxImpersonatedClients bounds the cache of per-caller clientsets; the least recently
+// used one is dropped first
+const maxImpersonatedClients = 256
+
+type impersonationKey struct{}
+
+// WithImpersonation returns a context whose Kubernetes calls are sent as user and groups
+// instead of the client's own identity, so cluster RBAC applies to the caller
+func WithImpersonation(ctx context.Context, user string, groups []string) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   groups,
+	})
+}
+
+// kube returns the clientset to use for ctx: the client's own, or one impersonating the
+// caller set with WithImpersonation. It never falls back to the client's own identity
+// for a context that asks for impersonation.
+func (c *Client) kube(ctx context.Context) (kubernetes.Interface, error) {
+	impersonate, ok := ctx.Value(impersonationKey{}).(rest.ImpersonationConfig)
+	if !ok {
+		return c.clientset, nil
+	}
+
+	if c.config == nil {
+		return nil, fmt.Errorf("cannot impersonate %s: the client has no REST config", impersonate.UserName)
+	}
+
+	key := impersonate.UserName + "\x00" + strings.Join(impersonate.Groups, "\x00")
+
+	c.impersonatedMu.Lock()
+	defer c.impersonatedMu.Unlock()
+
+	if clientset, ok := c.impersonated.Get(key); ok {
+		return clientset.(kubernetes.Interface), nil
+	}
+
+	config := rest.CopyConfig(c.config)
+	config.Impersonate = impersonate
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset impersonating %s: %w", impersonate.UserName, err)
+	}
+
+	c.impersonated.Add(key, clientset)
+	return clientset, nil
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ClusterClient is what the MCP server needs from a single cluster. *Client implements
+// it on top of any kubernetes.Interface, including the fake clientset used in tests.
+type ClusterClient interface {
+	ContextName() string
+	HealthCheck(ctx context.Context) error
+
+	ListPods(ctx context.Context, namespace string, opts ListOptions) (*ListPage[PodInfo], error)
+	ListServices(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ServiceInfo], error)
+	ListDeployments(ctx context.Context, namespace string, opts ListOptions) (*ListPage[DeploymentInfo], error)
+	ListStatefulSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[StatefulSetInfo], error)
+	ListDaemonSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[DaemonSetInfo], error)
+	ListReplicaSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ReplicaSetInfo], error)
+	ListJobs(ctx context.Context, namespace string, opts ListOptions) (*ListPage[JobInfo], error)
+	ListCronJobs(ctx context.Context, namespace string, opts ListOptions) (*ListPage[CronJobInfo], error)
+	ListConfigMaps(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ConfigMapInfo], error)
+	ListSecrets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[SecretInfo], error)
+	ListNamespaces(ctx context.Context, opts ListOptions) (*ListPage[NamespaceInfo], error)
+	ListNodes(ctx context.Context, opts ListOptions) (*ListPage[NodeInfo], error)
+
+	GetResource(ctx context.Context, identifier *types.ResourceIdentifier) (any, error)
+	GetSecret(ctx context.Context, namespace, name string, reveal []string) (*SecretInfo, error)
+	GetPodLogs(ctx context.Context, namespace, name string, opts PodLogOptions) (*PodLogs, error)
+
+	WatchResource(ctx context.Context, identifier *types.ResourceIdentifier, onEvent func(watch.EventType)) (<-chan struct{}, error)
+	NewResourceRegistry(debounce time.Duration) *ResourceRegistry
+}
+
+var _ ClusterClient = (*Client)(nil)
@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// inClusterContext is the context name reported when running with the pod's service account
+const inClusterContext = "in-cluster"
+
+// ContextInfo describes a context from the kubeconfig
+type ContextInfo struct {
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster"`
+	User      string `json:"user"`
+	Namespace string `json:"namespace,omitempty"`
+	Current   bool   `json:"current"` // the kubeconfig's current-context
+}
+
+// loadingRules follows kubectl: configPath may list several files separated by the OS
+// path list separator, and an empty configPath falls back to KUBECONFIG or ~/.kube/config
+func loadingRules(configPath string) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if configPath == "" {
+		return rules
+	}
+
+	if paths := filepath.SplitList(configPath); len(paths) > 1 {
+		rules.Precedence = paths
+	} else {
+		rules.ExplicitPath = configPath
+	}
+	return rules
+}
+
+// buildConfig returns the REST config for a context along with the context's resolved name
+func buildConfig(configPath, contextName string) (*rest.Config, string, error) {
+	// Use the in-cluster config unless a specific context was asked for
+	if contextName == "" {
+		if config, err := rest.InClusterConfig(); err == nil {
+			return config, inClusterContext, nil
+		}
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules(configPath),
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	)
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if contextName == "" {
+		contextName = rawConfig.CurrentContext
+	}
+	if _, ok := rawConfig.Contexts[contextName]; !ok {
+		return nil, "", fmt.Errorf("context %q not found in kubeconfig, available contexts: %s", contextName, strings.Join(contextNames(rawConfig.Contexts), ", "))
+	}
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	return config, contextName, nil
+}
+
+// ListContexts returns the contexts defined in the kubeconfig, sorted by name
+func ListContexts(configPath string) ([]ContextInfo, error) {
+	rawConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules(configPath),
+		&clientcmd.ConfigOverrides{},
+	).RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	var contexts []ContextInfo
+	for _, name := range contextNames(rawConfig.Contexts) {
+		ctx := rawConfig.Contexts[name]
+		contexts = append(contexts, ContextInfo{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == rawConfig.CurrentContext,
+		})
+	}
+
+	return contexts, nil
+}
+
+func contextNames[T any](contexts map[string]T) []string {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
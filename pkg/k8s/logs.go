@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultLogMaxBytes caps the size of returned logs so they fit in an LLM context
+const DefaultLogMaxBytes = 64 * 1024
+
+// maxLogLineBytes bounds a single log line; longer lines are cut rather than failing the read
+const maxLogLineBytes = 1024 * 1024
+
+// defaultContainerAnnotation names the container kubectl picks when none is given
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// PodLogOptions selects which log lines GetPodLogs returns
+type PodLogOptions struct {
+	Container    string
+	TailLines    *int64
+	SinceSeconds *int64
+	Previous     bool   // logs of the previous, terminated container instance (crash loops)
+	Timestamps   bool   // prefix every line with its RFC3339 timestamp
+	Grep         string // regular expression; only matching lines are kept
+	MaxBytes     int    // most recent lines are kept when the output exceeds this size
+}
+
+// GetPodLogs fetches container logs, filters them server-side and keeps the most recent
+// lines that fit into MaxBytes
+func (c *Client) GetPodLogs(ctx context.Context, namespace, name string, opts PodLogOptions) (*PodLogs, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	var filter *regexp.Regexp
+	if opts.Grep != "" {
+		var err error
+		if filter, err = regexp.Compile(opts.Grep); err != nil {
+			return nil, fmt.Errorf("invalid grep pattern %q: %w", opts.Grep, err)
+		}
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 || maxBytes > DefaultLogMaxBytes {
+		maxBytes = DefaultLogMaxBytes
+	}
+
+	container := opts.Container
+	if container == "" {
+		var err error
+		if container, err = c.defaultContainer(ctx, namespace, name); err != nil {
+			return nil, err
+		}
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := kube.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{
+		Container:    container,
+		TailLines:    opts.TailLines,
+		SinceSeconds: opts.SinceSeconds,
+		Previous:     opts.Previous,
+		Timestamps:   opts.Timestamps,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for pod %s/%s container %s: %w", namespace, name, container, err)
+	}
+	defer stream.Close()
+
+	logs := &PodLogs{
+		Pod:       name,
+		Namespace: namespace,
+		Container: container,
+		Previous:  opts.Previous,
+	}
+
+	lines, dropped, cut, err := newestLines(stream, filter, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs for pod %s/%s: %w", namespace, name, err)
+	}
+	logs.Truncated = dropped
+	logs.LinesCut = cut
+	logs.Lines = len(lines)
+	logs.Logs = strings.Join(lines, "\n")
+
+	return logs, nil
+}
+
+// newestLines reads log lines, drops those filter does not match and keeps a sliding
+// window of the newest lines within maxBytes. The newest line is always kept, cut to
+// maxBytes if it does not fit on its own. dropped reports whether older matching lines
+// fell out of the window, cut whether any returned line was shortened.
+func newestLines(r io.Reader, filter *regexp.Regexp, maxBytes int) (lines []string, dropped, cut bool, err error) {
+	size := 0
+	var cutLines []bool // whether each kept line was shortened
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for {
+		line, lineCut, err := readLogLine(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, false, err
+		}
+		if filter != nil && !filter.MatchString(line) {
+			continue
+		}
+
+		if len(line) > maxBytes {
+			line = cutLogLine(line, maxBytes)
+			lineCut = true
+		}
+		lines = append(lines, line)
+		cutLines = append(cutLines, lineCut)
+		size += len(line) + 1
+		for size > maxBytes && len(lines) > 1 {
+			size -= len(lines[0]) + 1
+			lines, cutLines = lines[1:], cutLines[1:]
+			dropped = true
+		}
+	}
+
+	for _, lineCut := range cutLines {
+		cut = cut || lineCut
+	}
+	return lines, dropped, cut, nil
+}
+
+// readLogLine reads one line without its newline. Bytes beyond maxLogLineBytes are
+// discarded and reported through cut.
+func readLogLine(reader *bufio.Reader) (line string, cut bool, err error) {
+	var buf []byte
+	for {
+		fragment, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", false, err
+		}
+		if room := maxLogLineBytes - len(buf); len(fragment) > room {
+			fragment, cut = []byte(cutLogLine(string(fragment), room)), true
+		}
+		buf = append(buf, fragment...)
+		if !isPrefix {
+			return string(buf), cut, nil
+		}
+	}
+}
+
+// cutLogLine shortens line to at most maxBytes without splitting a UTF-8 sequence
+func cutLogLine(line string, maxBytes int) string {
+	if len(line) <= maxBytes {
+		return line
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return line[:cut]
+}
+
+// defaultContainer picks the container to read logs from when the caller did not name one
+func (c *Client) defaultContainer(ctx context.Context, namespace, name string) (string, error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return "", err
+	}
+	pod, err := kube.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+	}
+
+	if container, ok := pod.Annotations[defaultContainerAnnotation]; ok && container != "" {
+		return container, nil
+	}
+
+	if len(pod.Spec.Containers) == 1 {
+		return pod.Spec.Containers[0].Name, nil
+	}
+
+	var names []string
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	return "", fmt.Errorf("pod %s/%s has %d containers, specify one of: %s", namespace, name, len(names), strings.Join(names, ", "))
+}
@@ -0,0 +1,311 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// nodeRoleLabelPrefix marks node roles, e.g. node-role.kubernetes.io/control-plane
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// nodePressureConditions are the node conditions that signal trouble when they are true
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// ListNodes lists the nodes of the cluster. Nodes belong to no namespace, so the
+// client's namespace scope does not restrict them.
+func (c *Client) ListNodes(ctx context.Context, opts ListOptions) (*ListPage[NodeInfo], error) {
+	listOptions, err := opts.toListOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := kube.CoreV1().Nodes().List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var nodeInfos []NodeInfo
+	for i := range nodes.Items {
+		nodeInfos = append(nodeInfos, newNodeInfo(&nodes.Items[i]))
+	}
+
+	return newListPage(nodeInfos, &nodes.ListMeta), nil
+}
+
+// GetNode returns a node with its conditions, the pods scheduled on it and how much of
+// its allocatable cpu and memory those pods request. With a restricted namespace scope
+// only the pods inside the scope are counted, and the node's events, which are recorded
+// in whatever namespace the reporting component chose, are left out.
+func (c *Client) GetNode(ctx context.Context, name string) (*NodeDetails, error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node, err := kube.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+
+	details := &NodeDetails{
+		NodeInfo:        newNodeInfo(node),
+		PodsInScopeOnly: !c.scope.All(),
+	}
+	if c.scope.All() {
+		details.Events = c.recentEventsOrNil(ctx, "Node", "", node.Name)
+	}
+	for _, condition := range node.Status.Conditions {
+		details.Conditions = append(details.Conditions, NodeCondition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+
+	pods, err := c.getNodePods(ctx, node.Name)
+	if err != nil {
+		c.logger.WithContext(ctx).Warnf("Failed to get pods on node %s: %v", node.Name, err)
+		return details, nil
+	}
+
+	details.Pods = []NodePod{}
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for i := range pods {
+		podRequests, podLimits := podRequestsAndLimits(&pods[i])
+		addResources(requests, podRequests)
+		addResources(limits, podLimits)
+		details.Pods = append(details.Pods, NodePod{
+			Name:           pods[i].Name,
+			Namespace:      pods[i].Namespace,
+			Phase:          string(pods[i].Status.Phase),
+			CPURequests:    quantityOf(podRequests, corev1.ResourceCPU),
+			MemoryRequests: quantityOf(podRequests, corev1.ResourceMemory),
+		})
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		allocatable := node.Status.Allocatable[name]
+		details.Allocated = append(details.Allocated, NodeAllocation{
+			Resource:        string(name),
+			Requests:        quantityOf(requests, name),
+			Limits:          quantityOf(limits, name),
+			Allocatable:     allocatable.String(),
+			RequestsPercent: percentOf(requests[name], allocatable),
+			LimitsPercent:   percentOf(limits[name], allocatable),
+		})
+	}
+
+	return details, nil
+}
+
+// getNodePods returns the pods on a node that have not terminated, sorted by namespace
+// and name, from every namespace in the client's scope
+func (c *Client) getNodePods(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("spec.nodeName", nodeName),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	).String()
+
+	namespaces := []string{metav1.NamespaceAll}
+	if !c.scope.All() {
+		var err error
+		if namespaces, err = c.scopedNamespaces(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, namespace := range namespaces {
+		list, err := kube.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+		}
+		// The field selector is checked again because not every clientset honours it
+		for _, pod := range list.Items {
+			if pod.Spec.NodeName == nodeName && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+				pods = append(pods, pod)
+			}
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+func newNodeInfo(node *corev1.Node) NodeInfo {
+	info := NodeInfo{
+		Name:           node.Name,
+		Status:         "Unknown",
+		Roles:          []string{},
+		Unschedulable:  node.Spec.Unschedulable,
+		Pressures:      []string{},
+		Taints:         []string{},
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		OSImage:        node.Status.NodeInfo.OSImage,
+		OS:             node.Status.NodeInfo.OperatingSystem,
+		Architecture:   node.Status.NodeInfo.Architecture,
+		Capacity:       newNodeResources(node.Status.Capacity),
+		Allocatable:    newNodeResources(node.Status.Allocatable),
+		Labels:         node.Labels,
+		CreatedAt:      node.CreationTimestamp.Time,
+	}
+
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			info.Roles = append(info.Roles, role)
+		}
+	}
+	if role := node.Labels["kubernetes.io/role"]; role != "" {
+		info.Roles = append(info.Roles, role)
+	}
+	sort.Strings(info.Roles)
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			switch condition.Status {
+			case corev1.ConditionTrue:
+				info.Status = "Ready"
+			case corev1.ConditionFalse:
+				info.Status = "NotReady"
+			}
+		}
+	}
+	// Pressures are reported in a fixed order so that lists stay stable
+	for _, pressure := range nodePressureConditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == pressure && condition.Status == corev1.ConditionTrue {
+				info.Pressures = append(info.Pressures, string(pressure))
+			}
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		rendered := taint.Key
+		if taint.Value != "" {
+			rendered += "=" + taint.Value
+		}
+		info.Taints = append(info.Taints, rendered+":"+string(taint.Effect))
+	}
+
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			info.InternalIP = address.Address
+			break
+		}
+	}
+
+	return info
+}
+
+func newNodeResources(resources corev1.ResourceList) NodeResources {
+	nodeResources := NodeResources{
+		CPU:    quantityOf(resources, corev1.ResourceCPU),
+		Memory: quantityOf(resources, corev1.ResourceMemory),
+		Pods:   quantityOf(resources, corev1.ResourcePods),
+	}
+	if storage, ok := resources[corev1.ResourceEphemeralStorage]; ok {
+		nodeResources.EphemeralStorage = storage.String()
+	}
+	return nodeResources
+}
+
+// podRequestsAndLimits works out what a pod reserves on its node the way the scheduler
+// does: the sum of its containers and restartable (sidecar) init containers, or the
+// largest regular init container plus the sidecars started before it if that is more,
+// plus the pod overhead
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+		addResources(limits, container.Resources.Limits)
+	}
+
+	initRequests, initLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	sidecarRequests, sidecarLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addResources(sidecarRequests, container.Resources.Requests)
+			addResources(sidecarLimits, container.Resources.Limits)
+			continue
+		}
+		maxResources(initRequests, withResources(sidecarRequests, container.Resources.Requests))
+		maxResources(initLimits, withResources(sidecarLimits, container.Resources.Limits))
+	}
+
+	addResources(requests, sidecarRequests)
+	addResources(limits, sidecarLimits)
+	maxResources(requests, initRequests)
+	maxResources(limits, initLimits)
+
+	addResources(requests, pod.Spec.Overhead)
+	addResources(limits, pod.Spec.Overhead)
+	return requests, limits
+}
+
+// addResources adds every quantity of add to total
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// maxResources raises every quantity of total to at least the one in other
+func maxResources(total, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// withResources returns the sum of base and add without changing either
+func withResources(base, add corev1.ResourceList) corev1.ResourceList {
+	sum := base.DeepCopy()
+	addResources(sum, add)
+	return sum
+}
+
+// quantityOf renders one quantity of a resource list, "0" when it is missing
+func quantityOf(resources corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, ok := resources[name]
+	if !ok {
+		return "0"
+	}
+	return quantity.String()
+}
+
+// percentOf returns used as a whole percentage of total, 0 when total is zero
+func percentOf(used, total resource.Quantity) int {
+	if total.IsZero() {
+		return 0
+	}
+	return int(used.MilliValue() * 100 / total.MilliValue())
+}
@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListOptions narrows the objects returned by the List* methods. Selectors use the
+// standard Kubernetes syntax and are filtered by the API server.
+type ListOptions struct {
+	LabelSelector   string // e.g. "app=web,tier in (frontend,backend)"
+	FieldSelector   string // e.g. "spec.nodeName=node-3,status.phase!=Running"
+	Limit           int64  // maximum number of objects per request, 0 for no limit
+	Continue        string // continue token from a previous limited request
+	ResourceVersion string // resourceVersion the list must be at least as fresh as
+}
+
+// Validate checks the selector syntax so malformed selectors fail before a request is sent
+func (o ListOptions) Validate() error {
+	if o.LabelSelector != "" {
+		if _, err := labels.Parse(o.LabelSelector); err != nil {
+			return fmt.Errorf("invalid label selector %q: %w", o.LabelSelector, err)
+		}
+	}
+
+	if o.FieldSelector != "" {
+		if _, err := fields.ParseSelector(o.FieldSelector); err != nil {
+			return fmt.Errorf("invalid field selector %q: %w", o.FieldSelector, err)
+		}
+	}
+
+	if o.Limit < 0 {
+		return fmt.Errorf("invalid limit %d: must not be negative", o.Limit)
+	}
+
+	if o.Continue != "" && o.ResourceVersion != "" {
+		return fmt.Errorf("resourceVersion cannot be combined with a continue token")
+	}
+
+	return nil
+}
+
+// toListOptions validates the options and converts them to their API form
+func (o ListOptions) toListOptions() (metav1.ListOptions, error) {
+	if err := o.Validate(); err != nil {
+		return metav1.ListOptions{}, err
+	}
+
+	return metav1.ListOptions{
+		LabelSelector:   o.LabelSelector,
+		FieldSelector:   o.FieldSelector,
+		Limit:           o.Limit,
+		Continue:        o.Continue,
+		ResourceVersion: o.ResourceVersion,
+	}, nil
+}
+
+// ListPage is one page of list results. Continue is empty once the last page has been returned.
+type ListPage[T any] struct {
+	Items              []T    `json:"items"`
+	Continue           string `json:"continue,omitempty"`
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"` // estimate provided by the API server
+}
+
+func newListPage[T any](items []T, listMeta *metav1.ListMeta) *ListPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return &ListPage[T]{
+		Items:              items,
+		Continue:           listMeta.Continue,
+		RemainingItemCount: listMeta.RemainingItemCount,
+	}
+}
@@ -0,0 +1,249 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResourceRegistry keeps an up-to-date view of the pods, services, deployments,
+// statefulsets, daemonsets and cronjobs in the client's namespace scope using shared
+// informers, and reports membership changes to listeners. ReplicaSets and Jobs are left
+// out: every rollout or cronjob run adds one and old ones are kept as history, so they
+// would crowd the list and trigger list_changed on every rollout. They stay readable
+// through the k8s:// URI templates and their list_* tools.
+type ResourceRegistry struct {
+	client    *Client
+	factories []informers.SharedInformerFactory
+	pods      []cache.SharedIndexInformer
+	services  []cache.SharedIndexInformer
+	deploys   []cache.SharedIndexInformer
+	sets      []cache.SharedIndexInformer // statefulsets
+	daemons   []cache.SharedIndexInformer
+	crons     []cache.SharedIndexInformer
+	logger    *logrus.Logger
+
+	debounce  time.Duration
+	mu        sync.Mutex
+	listeners []func()
+	pending   *time.Timer
+}
+
+// NewResourceRegistry creates a registry backed by the client's clientset. Change
+// notifications are coalesced so that listeners run at most once per debounce window.
+func (c *Client) NewResourceRegistry(debounce time.Duration) *ResourceRegistry {
+	return &ResourceRegistry{
+		client:   c,
+		logger:   c.logger,
+		debounce: debounce,
+	}
+}
+
+// Start runs the informers until ctx is cancelled and blocks until their caches are synced.
+// A restricted namespace scope is watched one namespace at a time; namespaces created
+// later that match a glob pattern are picked up the next time the registry is started.
+func (r *ResourceRegistry) Start(ctx context.Context) error {
+	namespaces := []string{metav1.NamespaceAll}
+	if !r.client.scope.All() {
+		var err error
+		if namespaces, err = r.client.scopedNamespaces(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Only additions and deletions change the resource list; updates are
+	// reported through resource subscriptions instead.
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { r.scheduleNotify() },
+		DeleteFunc: func(obj interface{}) { r.scheduleNotify() },
+	}
+
+	for _, namespace := range namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(r.client.clientset, 0, informers.WithNamespace(namespace))
+		pods := factory.Core().V1().Pods().Informer()
+		services := factory.Core().V1().Services().Informer()
+		deploys := factory.Apps().V1().Deployments().Informer()
+		sets := factory.Apps().V1().StatefulSets().Informer()
+		daemons := factory.Apps().V1().DaemonSets().Informer()
+		crons := factory.Batch().V1().CronJobs().Informer()
+
+		for _, informer := range []cache.SharedIndexInformer{pods, services, deploys, sets, daemons, crons} {
+			if _, err := informer.AddEventHandler(handler); err != nil {
+				r.logger.Errorf("Failed to register informer event handler: %v", err)
+			}
+		}
+
+		r.factories = append(r.factories, factory)
+		r.pods = append(r.pods, pods)
+		r.services = append(r.services, services)
+		r.deploys = append(r.deploys, deploys)
+		r.sets = append(r.sets, sets)
+		r.daemons = append(r.daemons, daemons)
+		r.crons = append(r.crons, crons)
+	}
+
+	for _, factory := range r.factories {
+		factory.Start(ctx.Done())
+	}
+
+	go func() {
+		<-ctx.Done()
+		r.mu.Lock()
+		if r.pending != nil {
+			r.pending.Stop()
+		}
+		r.mu.Unlock()
+		for _, factory := range r.factories {
+			factory.Shutdown()
+		}
+	}()
+
+	for _, factory := range r.factories {
+		for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				return fmt.Errorf("failed to sync informer cache for %v", informerType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// OnChange registers a listener that is called after the set of known resources changes.
+func (r *ResourceRegistry) OnChange(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Resources returns the MCP resource descriptions for every object currently in the caches.
+func (r *ResourceRegistry) Resources() []types.Resource {
+	var resources []types.Resource
+
+	for _, obj := range storeObjects(r.pods) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypePod, pod.Namespace, pod.Name,
+			fmt.Sprintf("Kubernetes Pod in namespace %s (Node: %s)", pod.Namespace, pod.Spec.NodeName)))
+	}
+
+	for _, obj := range storeObjects(r.services) {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypeService, svc.Namespace, svc.Name,
+			fmt.Sprintf("Kubernetes Service in namespace %s (Type: %s)", svc.Namespace, svc.Spec.Type)))
+	}
+
+	for _, obj := range storeObjects(r.deploys) {
+		deploy, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypeDeployment, deploy.Namespace, deploy.Name,
+			fmt.Sprintf("Kubernetes Deployment in namespace %s (Strategy: %s)", deploy.Namespace, deploy.Spec.Strategy.Type)))
+	}
+
+	for _, obj := range storeObjects(r.sets) {
+		set, ok := obj.(*appsv1.StatefulSet)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypeStatefulSet, set.Namespace, set.Name,
+			fmt.Sprintf("Kubernetes StatefulSet in namespace %s (Service: %s)", set.Namespace, set.Spec.ServiceName)))
+	}
+
+	for _, obj := range storeObjects(r.daemons) {
+		daemon, ok := obj.(*appsv1.DaemonSet)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypeDaemonSet, daemon.Namespace, daemon.Name,
+			fmt.Sprintf("Kubernetes DaemonSet in namespace %s", daemon.Namespace)))
+	}
+
+	for _, obj := range storeObjects(r.crons) {
+		cronjob, ok := obj.(*batchv1.CronJob)
+		if !ok {
+			continue
+		}
+		resources = append(resources, newResource(types.ResourceTypeCronJob, cronjob.Namespace, cronjob.Name,
+			fmt.Sprintf("Kubernetes CronJob in namespace %s (Schedule: %s)", cronjob.Namespace, cronjob.Spec.Schedule)))
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].URI < resources[j].URI
+	})
+
+	return resources
+}
+
+// scheduleNotify starts the debounce window if one is not already pending. Events
+// arriving while the window is open are folded into the same notification.
+func (r *ResourceRegistry) scheduleNotify() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending != nil {
+		return
+	}
+
+	r.pending = time.AfterFunc(r.debounce, func() {
+		r.mu.Lock()
+		r.pending = nil
+		listeners := append([]func(){}, r.listeners...)
+		r.mu.Unlock()
+
+		for _, fn := range listeners {
+			fn()
+		}
+	})
+}
+
+// storeObjects returns the cached objects of all informers of one kind
+func storeObjects(informers []cache.SharedIndexInformer) []interface{} {
+	var objects []interface{}
+	for _, informer := range informers {
+		objects = append(objects, informer.GetStore().List()...)
+	}
+	return objects
+}
+
+func newResource(resourceType types.K8sResourceType, namespace, name, description string) types.Resource {
+	identifier := types.ResourceIdentifier{
+		Type:      resourceType,
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	return types.Resource{
+		URI:         identifier.ToURI(),
+		Name:        fmt.Sprintf("%s: %s/%s", kindNames[resourceType], namespace, name),
+		Description: description,
+		MimeType:    "text/markdown",
+	}
+}
+
+var kindNames = map[types.K8sResourceType]string{
+	types.ResourceTypePod:         "Pod",
+	types.ResourceTypeService:     "Service",
+	types.ResourceTypeDeployment:  "Deployment",
+	types.ResourceTypeStatefulSet: "StatefulSet",
+	types.ResourceTypeDaemonSet:   "DaemonSet",
+	types.ResourceTypeCronJob:     "CronJob",
+}
@@ -0,0 +1,219 @@
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrOutOfScope is returned for requests that fall outside the client's namespace scope
+var ErrOutOfScope = errors.New("outside the configured namespace scope")
+
+// NamespaceScope is the set of namespaces a client may read. Patterns use path.Match
+// glob syntax, e.g. "team-*". An empty scope, or one containing "*", allows every namespace.
+type NamespaceScope struct {
+	patterns []string
+}
+
+// NewNamespaceScope creates a scope from namespace names and glob patterns
+func NewNamespaceScope(patterns []string) NamespaceScope {
+	var scope NamespaceScope
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			scope.patterns = append(scope.patterns, pattern)
+		}
+	}
+	return scope
+}
+
+// All reports whether the scope allows every namespace
+func (s NamespaceScope) All() bool {
+	if len(s.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range s.patterns {
+		if pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether a namespace is inside the scope
+func (s NamespaceScope) Contains(namespace string) bool {
+	if s.All() {
+		return true
+	}
+	for _, pattern := range s.patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error naming the allowed namespaces when namespace is outside the scope
+func (s NamespaceScope) Check(namespace string) error {
+	if s.Contains(namespace) {
+		return nil
+	}
+	return fmt.Errorf("namespace %q is %w (allowed: %s)", namespace, ErrOutOfScope, s)
+}
+
+func (s NamespaceScope) String() string {
+	if s.All() {
+		return "*"
+	}
+	return strings.Join(s.patterns, ", ")
+}
+
+// literal reports whether the scope only names namespaces, so it can be resolved without
+// listing the namespaces of the cluster
+func (s NamespaceScope) literal() bool {
+	for _, pattern := range s.patterns {
+		if strings.ContainsAny(pattern, `*?[\`) {
+			return false
+		}
+	}
+	return true
+}
+
+// scopedNamespaces resolves the client's scope to the sorted namespaces it currently covers
+func (c *Client) scopedNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []string
+	if c.scope.literal() {
+		namespaces = append(namespaces, c.scope.patterns...)
+	} else {
+		kube, err := c.kube(ctx)
+		if err != nil {
+			return nil, err
+		}
+		list, err := kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve namespace scope %s: %w", c.scope, err)
+		}
+		for _, ns := range list.Items {
+			if c.scope.Contains(ns.Name) {
+				namespaces = append(namespaces, ns.Name)
+			}
+		}
+	}
+
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// scopeContinue is the continue token of a list that spans several namespaces. It names
+// the namespace to resume in and the API server's continue token within it.
+type scopeContinue struct {
+	Namespace string `json:"ns"`
+	Continue  string `json:"c,omitempty"`
+}
+
+func encodeScopeContinue(namespace, continueToken string) string {
+	data, err := json.Marshal(scopeContinue{Namespace: namespace, Continue: continueToken})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeScopeContinue(token string) (scopeContinue, error) {
+	var c scopeContinue
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("invalid continue token: %w", err)
+	}
+	return c, nil
+}
+
+// namespacedListFunc lists one page of objects from a single namespace
+type namespacedListFunc[T any] func(ctx context.Context, namespace string, opts metav1.ListOptions) (*ListPage[T], error)
+
+// listInScope lists objects from namespace, or from every namespace in the client's scope
+// when namespace is empty. A restricted scope is listed one namespace at a time, in
+// name order, so the limit and continue token span namespaces.
+func listInScope[T any](ctx context.Context, c *Client, namespace string, opts ListOptions, list namespacedListFunc[T]) (*ListPage[T], error) {
+	listOptions, err := opts.toListOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace != "" || c.scope.All() {
+		if namespace != "" {
+			if err := c.scope.Check(namespace); err != nil {
+				return nil, err
+			}
+		}
+		return list(ctx, namespace, listOptions)
+	}
+
+	namespaces, err := c.scopedNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, continueToken := 0, ""
+	if opts.Continue != "" {
+		resume, err := decodeScopeContinue(opts.Continue)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.SearchStrings(namespaces, resume.Namespace)
+		if start < len(namespaces) && namespaces[start] == resume.Namespace {
+			continueToken = resume.Continue
+		}
+	}
+
+	page := &ListPage[T]{Items: []T{}}
+	for i := start; i < len(namespaces); i++ {
+		namespaceOptions := listOptions
+		namespaceOptions.Continue = continueToken
+		continueToken = ""
+		if opts.Limit > 0 {
+			namespaceOptions.Limit = opts.Limit - int64(len(page.Items))
+		}
+
+		result, err := list(ctx, namespaces[i], namespaceOptions)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, result.Items...)
+
+		if result.Continue != "" {
+			page.Continue = encodeScopeContinue(namespaces[i], result.Continue)
+			return page, nil
+		}
+		if opts.Limit > 0 && int64(len(page.Items)) >= opts.Limit && i+1 < len(namespaces) {
+			page.Continue = encodeScopeContinue(namespaces[i+1], "")
+			return page, nil
+		}
+	}
+
+	return page, nil
+}
+
+// checkScope refuses identifiers outside the client's namespace scope. Namespaces
+// themselves are in scope when their name is; other cluster-scoped objects such as
+// nodes belong to no namespace and are always in scope.
+func (c *Client) checkScope(identifier *types.ResourceIdentifier) error {
+	switch {
+	case identifier.Type == types.ResourceTypeNamespace:
+		return c.scope.Check(identifier.Name)
+	case identifier.Type.ClusterScoped():
+		return nil
+	}
+	return c.scope.Check(identifier.Namespace)
+}
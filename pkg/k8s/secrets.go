@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListSecrets lists secrets with their keys, sizes and fingerprints, never their values
+func (c *Client) ListSecrets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[SecretInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listSecrets)
+}
+
+func (c *Client) listSecrets(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[SecretInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := kube.CoreV1().Secrets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in namespace %s: %w", namespace, err)
+	}
+
+	var secretInfos []SecretInfo
+	for i := range secrets.Items {
+		secretInfos = append(secretInfos, *newSecretInfo(&secrets.Items[i], nil))
+	}
+
+	return newListPage(secretInfos, &secrets.ListMeta), nil
+}
+
+// GetSecret returns a secret with its values redacted, except for the keys in reveal.
+// Deciding which keys may be revealed is up to the caller; every key in reveal must exist.
+func (c *Client) GetSecret(ctx context.Context, namespace, name string, reveal []string) (*SecretInfo, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := kube.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	var missing []string
+	for _, key := range reveal {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("secret %s/%s has no key %s", namespace, name, strings.Join(missing, ", "))
+	}
+
+	return newSecretInfo(secret, reveal), nil
+}
+
+// newSecretInfo describes a secret's keys in sorted order. Only the values of the keys
+// in reveal are copied; annotations are left out entirely because
+// kubectl.kubernetes.io/last-applied-configuration holds the whole secret.
+func newSecretInfo(secret *corev1.Secret, reveal []string) *SecretInfo {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	info := &SecretInfo{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Type:      string(secret.Type),
+		Keys:      make([]SecretKeyInfo, 0, len(keys)),
+		Labels:    secret.Labels,
+		CreatedAt: secret.CreationTimestamp.Time,
+	}
+	for _, key := range keys {
+		value := secret.Data[key]
+		sum := sha256.Sum256(value)
+		keyInfo := SecretKeyInfo{
+			Key:    key,
+			Size:   len(value),
+			SHA256: hex.EncodeToString(sum[:]),
+		}
+		if slices.Contains(reveal, key) {
+			keyInfo.Revealed = true
+			keyInfo.Value = string(value)
+		}
+		info.Keys = append(info.Keys, keyInfo)
+	}
+
+	return info
+}
@@ -44,6 +44,263 @@ type DeploymentInfo struct {
 	Strategy        string            `json:"strategy"` // indicates deployment approach (RollingUpdate vs Recreate)
 }
 
+// ContainerInfo represents the state of one container of a pod
+type ContainerInfo struct {
+	Name     string `json:"name"`
+	Image    string `json:"image"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+	State    string `json:"state"` // Running, or Waiting/Terminated with the reason
+}
+
+// PodDetails represents a single pod as returned by GetPod
+type PodDetails struct {
+	PodInfo
+	Containers []ContainerInfo `json:"containers"`
+	Events     []EventInfo     `json:"recentEvents"`
+	Conditions []string        `json:"conditions"` // conditions that are currently true
+}
+
+// ServiceDetails represents a single service as returned by GetService
+type ServiceDetails struct {
+	ServiceInfo
+	Selector       map[string]string `json:"selector"`
+	Endpoints      []EndpointInfo    `json:"endpoints"` // nil when the endpointslices could not be read
+	ReadyEndpoints int               `json:"readyEndpoints"`
+	MatchingPods   *int              `json:"matchingPods,omitempty"` // pods matched by the selector; nil without a selector or when pods could not be read
+	Events         []EventInfo       `json:"recentEvents"`
+}
+
+// DeploymentDetails represents a single deployment as returned by GetDeployment
+type DeploymentDetails struct {
+	DeploymentInfo
+	Selector   map[string]string `json:"selector"`
+	Conditions []string          `json:"conditions"` // "<type>: <message>" of conditions that are currently true
+	Events     []EventInfo       `json:"recentEvents"`
+}
+
+// StatefulSetInfo represents essential statefulset information.
+type StatefulSetInfo struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	TotalReplicas   int32             `json:"totalReplicas"`
+	ReadyReplicas   int32             `json:"readyReplicas"`
+	CurrentReplicas int32             `json:"currentReplicas"` // pods at the current revision
+	UpdatedReplicas int32             `json:"updatedReplicas"` // pods at the update revision
+	ServiceName     string            `json:"serviceName"`     // headless service that gives the pods stable DNS names
+	UpdateStrategy  string            `json:"updateStrategy"`  // RollingUpdate or OnDelete
+	Partition       int32             `json:"partition,omitempty"`
+	Labels          map[string]string `json:"labels"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+// StatefulSetOrdinal represents the pod of one statefulset ordinal
+type StatefulSetOrdinal struct {
+	Ordinal int32  `json:"ordinal"`
+	Pod     string `json:"pod"`
+	Phase   string `json:"phase"` // empty when the pod does not exist
+	Ready   bool   `json:"ready"`
+}
+
+// VolumeClaimTemplateInfo represents one of the PVC templates of a statefulset
+type VolumeClaimTemplateInfo struct {
+	Name         string   `json:"name"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	AccessModes  []string `json:"accessModes"`
+	Storage      string   `json:"storage"` // requested size, e.g. 10Gi
+}
+
+// StatefulSetDetails represents a single statefulset as returned by GetStatefulSet
+type StatefulSetDetails struct {
+	StatefulSetInfo
+	Selector             map[string]string         `json:"selector"`
+	PodManagementPolicy  string                    `json:"podManagementPolicy"` // OrderedReady or Parallel
+	CurrentRevision      string                    `json:"currentRevision"`
+	UpdateRevision       string                    `json:"updateRevision"`
+	Ordinals             []StatefulSetOrdinal      `json:"ordinals"` // nil when the pods could not be read
+	VolumeClaimTemplates []VolumeClaimTemplateInfo `json:"volumeClaimTemplates"`
+	Conditions           []string                  `json:"conditions"` // "<type>: <message>" of conditions that are currently true
+	Events               []EventInfo               `json:"recentEvents"`
+}
+
+// DaemonSetInfo represents essential daemonset information.
+type DaemonSetInfo struct {
+	Name                   string            `json:"name"`
+	Namespace              string            `json:"namespace"`
+	DesiredNumberScheduled int32             `json:"desiredNumberScheduled"` // nodes that should run the daemon pod
+	CurrentNumberScheduled int32             `json:"currentNumberScheduled"` // nodes that should run it and do
+	NumberMisscheduled     int32             `json:"numberMisscheduled"`     // nodes that run it but shouldn't
+	NumberReady            int32             `json:"numberReady"`
+	NumberAvailable        int32             `json:"numberAvailable"`
+	UpdatedNumberScheduled int32             `json:"updatedNumberScheduled"`
+	UpdateStrategy         string            `json:"updateStrategy"` // RollingUpdate or OnDelete
+	NodeSelector           map[string]string `json:"nodeSelector,omitempty"`
+	Labels                 map[string]string `json:"labels"`
+	CreatedAt              time.Time         `json:"createdAt"`
+}
+
+// DaemonSetDetails represents a single daemonset as returned by GetDaemonSet
+type DaemonSetDetails struct {
+	DaemonSetInfo
+	Selector   map[string]string `json:"selector"`
+	Conditions []string          `json:"conditions"` // "<type>: <message>" of conditions that are currently true
+	Events     []EventInfo       `json:"recentEvents"`
+}
+
+// ReplicaSetInfo represents essential replicaset information.
+type ReplicaSetInfo struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	TotalReplicas     int32             `json:"totalReplicas"`
+	ReadyReplicas     int32             `json:"readyReplicas"`
+	AvailableReplicas int32             `json:"availableReplicas"`
+	Owner             string            `json:"owner,omitempty"`    // deployment that manages the replicaset
+	Revision          string            `json:"revision,omitempty"` // rollout revision of the owning deployment
+	Labels            map[string]string `json:"labels"`
+	CreatedAt         time.Time         `json:"createdAt"`
+}
+
+// ReplicaSetDetails represents a single replicaset as returned by GetReplicaSet
+type ReplicaSetDetails struct {
+	ReplicaSetInfo
+	Selector   map[string]string `json:"selector"`
+	Conditions []string          `json:"conditions"` // "<type>: <message>" of conditions that are currently true
+	Events     []EventInfo       `json:"recentEvents"`
+}
+
+// JobInfo represents essential job information.
+type JobInfo struct {
+	Name                  string            `json:"name"`
+	Namespace             string            `json:"namespace"`
+	Status                string            `json:"status"` // Complete, Failed, Suspended, Running or Pending
+	Completions           int32             `json:"completions"`
+	Parallelism           int32             `json:"parallelism"`
+	Active                int32             `json:"active"`
+	Succeeded             int32             `json:"succeeded"`
+	Failed                int32             `json:"failed"`                          // failed pods, counted against BackoffLimit
+	BackoffLimit          int32             `json:"backoffLimit"`                    // failed pods tolerated before the job fails
+	ActiveDeadlineSeconds *int64            `json:"activeDeadlineSeconds,omitempty"` // how long the job may run before it is failed
+	Owner                 string            `json:"owner,omitempty"`                 // cronjob that spawned the job
+	StartTime             *time.Time        `json:"startTime,omitempty"`
+	CompletionTime        *time.Time        `json:"completionTime,omitempty"`
+	Labels                map[string]string `json:"labels"`
+	CreatedAt             time.Time         `json:"createdAt"`
+}
+
+// JobPodFailure describes a container of a job pod that terminated with an error
+type JobPodFailure struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Reason    string `json:"reason"` // e.g. Error, OOMKilled
+	ExitCode  int32  `json:"exitCode"`
+	Message   string `json:"message,omitempty"`
+}
+
+// JobDetails represents a single job as returned by GetJob
+type JobDetails struct {
+	JobInfo
+	FailureReason  string          `json:"failureReason,omitempty"` // reason of the Failed condition, e.g. BackoffLimitExceeded
+	FailureMessage string          `json:"failureMessage,omitempty"`
+	PodFailures    []JobPodFailure `json:"podFailures"` // nil when no pod failed or the pods could not be read
+	Conditions     []string        `json:"conditions"`  // "<type>: <message>" of conditions that are currently true
+	Events         []EventInfo     `json:"recentEvents"`
+}
+
+// CronJobInfo represents essential cronjob information.
+type CronJobInfo struct {
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	Schedule           string            `json:"schedule"`
+	TimeZone           string            `json:"timeZone,omitempty"`
+	Suspended          bool              `json:"suspended"`
+	ConcurrencyPolicy  string            `json:"concurrencyPolicy"` // Allow, Forbid or Replace
+	Active             int               `json:"active"`            // jobs currently running
+	LastScheduleTime   *time.Time        `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *time.Time        `json:"lastSuccessfulTime,omitempty"`
+	NextScheduleTime   *time.Time        `json:"nextScheduleTime,omitempty"` // nil when suspended or the schedule can't be parsed
+	ScheduleError      string            `json:"scheduleError,omitempty"`
+	Labels             map[string]string `json:"labels"`
+	CreatedAt          time.Time         `json:"createdAt"`
+}
+
+// CronJobDetails represents a single cronjob as returned by GetCronJob
+type CronJobDetails struct {
+	CronJobInfo
+	StartingDeadlineSeconds    *int64      `json:"startingDeadlineSeconds,omitempty"`
+	SuccessfulJobsHistoryLimit int32       `json:"successfulJobsHistoryLimit"`
+	FailedJobsHistoryLimit     int32       `json:"failedJobsHistoryLimit"`
+	RecentJobs                 []JobInfo   `json:"recentJobs"`          // newest first
+	ConsecutiveFailures        int         `json:"consecutiveFailures"` // failed jobs since the last one that succeeded, at most FailedJobsHistoryLimit
+	Events                     []EventInfo `json:"recentEvents"`
+}
+
+// NodeResources holds the cpu, memory, pods and ephemeral storage quantities of a node,
+// rendered the way kubectl shows them, e.g. "3920m" or "16Gi"
+type NodeResources struct {
+	CPU              string `json:"cpu"`
+	Memory           string `json:"memory"`
+	Pods             string `json:"pods"`
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+// NodeInfo represents essential node information.
+type NodeInfo struct {
+	Name           string            `json:"name"`
+	Status         string            `json:"status"` // Ready, NotReady or Unknown, from the Ready condition
+	Roles          []string          `json:"roles"`
+	Unschedulable  bool              `json:"unschedulable"` // cordoned
+	Pressures      []string          `json:"pressures"`     // MemoryPressure, DiskPressure, PIDPressure and NetworkUnavailable when true
+	Taints         []string          `json:"taints"`        // "<key>[=<value>]:<effect>"
+	KubeletVersion string            `json:"kubeletVersion"`
+	OSImage        string            `json:"osImage"`
+	OS             string            `json:"os"`
+	Architecture   string            `json:"architecture"`
+	InternalIP     string            `json:"internalIP"`
+	Capacity       NodeResources     `json:"capacity"`
+	Allocatable    NodeResources     `json:"allocatable"` // capacity minus what is reserved for the system
+	Labels         map[string]string `json:"labels"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+// NodeCondition is one condition of a node. Unlike workloads, node conditions are kept
+// whatever their status, since pressure conditions are healthy when False.
+type NodeCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NodePod is a pod scheduled on a node with its effective resource requests
+type NodePod struct {
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	Phase          string `json:"phase"`
+	CPURequests    string `json:"cpuRequests"`
+	MemoryRequests string `json:"memoryRequests"`
+}
+
+// NodeAllocation compares the summed requests and limits of a node's pods with what the
+// node can allocate for one resource
+type NodeAllocation struct {
+	Resource        string `json:"resource"` // cpu or memory
+	Requests        string `json:"requests"`
+	Limits          string `json:"limits"`
+	Allocatable     string `json:"allocatable"`
+	RequestsPercent int    `json:"requestsPercent"`
+	LimitsPercent   int    `json:"limitsPercent"` // may exceed 100 on overcommitted nodes
+}
+
+// NodeDetails represents a single node as returned by GetNode
+type NodeDetails struct {
+	NodeInfo
+	Conditions      []NodeCondition  `json:"conditions"`
+	Pods            []NodePod        `json:"pods"`                      // pods that have not terminated, nil when they could not be read
+	Allocated       []NodeAllocation `json:"allocated"`                 // nil when the pods could not be read
+	PodsInScopeOnly bool             `json:"podsInScopeOnly,omitempty"` // pods and allocation only cover the client's namespace scope
+	Events          []EventInfo      `json:"recentEvents"`              // nil unless the namespace scope is "*"
+}
+
 // NamespaceInfo represents essential namespace information.
 type NamespaceInfo struct {
 	Name      string            `json:"name"`
@@ -54,9 +311,60 @@ type NamespaceInfo struct {
 
 // ConfigMapInfo represents essential configmap information
 type ConfigMapInfo struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Data       map[string]string `json:"data"`
+	BinaryData map[string]int    `json:"binaryData,omitempty"` // size in bytes of each binaryData value; the bytes are left out
+	Labels     map[string]string `json:"labels"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// SecretKeyInfo describes one key of a secret. Value is only set for keys that were
+// explicitly revealed.
+type SecretKeyInfo struct {
+	Key      string `json:"key"`
+	Size     int    `json:"size"`   // length of the decoded value in bytes
+	SHA256   string `json:"sha256"` // hex fingerprint of the decoded value, to compare values without seeing them
+	Revealed bool   `json:"revealed,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// SecretInfo represents essential secret information with its values redacted
+type SecretInfo struct {
 	Name      string            `json:"name"`
 	Namespace string            `json:"namespace"`
-	Data      map[string]string `json:"data"`
+	Type      string            `json:"type"` // e.g. Opaque, kubernetes.io/tls
+	Keys      []SecretKeyInfo   `json:"keys"`
 	Labels    map[string]string `json:"labels"`
 	CreatedAt time.Time         `json:"createdAt"`
 }
+
+// PodLogs represents the filtered log output of a single container
+type PodLogs struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Container string `json:"container"`
+	Previous  bool   `json:"previous"`  // logs come from the previous container instance
+	Lines     int    `json:"lines"`     // number of lines returned after filtering
+	Truncated bool   `json:"truncated"` // older lines were dropped to fit the byte limit
+	LinesCut  bool   `json:"linesCut"`  // at least one returned line was shortened to fit a size limit
+	Logs      string `json:"logs"`
+}
+
+// EventInfo represents a (possibly merged) Kubernetes event about an object
+type EventInfo struct {
+	Type      string    `json:"type"` // Normal or Warning
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// EndpointInfo represents one backend address of a service, taken from its EndpointSlices
+type EndpointInfo struct {
+	Address   string `json:"address"`
+	Ready     bool   `json:"ready"`
+	TargetPod string `json:"targetPod,omitempty"`
+	Node      string `json:"node,omitempty"`
+}
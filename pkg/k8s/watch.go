@@ -0,0 +1,175 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// WatchResource watches a single object and calls onEvent for every change to it
+// (including status updates and deletion) until ctx is cancelled. The watch is
+// re-established automatically when the API server closes it. The returned channel is
+// closed once the watch has stopped and makes no more API calls.
+func (c *Client) WatchResource(ctx context.Context, identifier *types.ResourceIdentifier, onEvent func(watch.EventType)) (<-chan struct{}, error) {
+	if err := c.checkScope(identifier); err != nil {
+		return nil, err
+	}
+
+	lw, err := c.singleObjectListWatch(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the current resourceVersion so that only real changes are reported
+	list, err := lw.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s %s/%s: %w", identifier.Type, identifier.Namespace, identifier.Name, err)
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list metadata: %w", err)
+	}
+
+	watcher, err := watchtools.NewRetryWatcher(listMeta.GetResourceVersion(), lw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s %s/%s: %w", identifier.Type, identifier.Namespace, identifier.Name, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			watcher.Stop()
+			<-watcher.Done()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				switch event.Type {
+				case watch.Added, watch.Modified, watch.Deleted:
+					onEvent(event.Type)
+				case watch.Error:
+					c.logger.WithContext(ctx).Warnf("Watch error for %s: %v", identifier.ToURI(), event.Object)
+				}
+			}
+		}
+	}()
+
+	return done, nil
+}
+
+// singleObjectListWatch builds a ListWatch restricted to one object by name
+func (c *Client) singleObjectListWatch(ctx context.Context, identifier *types.ResourceIdentifier) (*cache.ListWatch, error) {
+	selector := fields.OneTermEqualSelector("metadata.name", identifier.Name).String()
+	namespace := identifier.Namespace
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+	var watchFn func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+
+	switch identifier.Type {
+	case types.ResourceTypePod:
+		pods := kube.CoreV1().Pods(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return pods.List(ctx, opts)
+		}
+		watchFn = pods.Watch
+	case types.ResourceTypeService:
+		services := kube.CoreV1().Services(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return services.List(ctx, opts)
+		}
+		watchFn = services.Watch
+	case types.ResourceTypeDeployment:
+		deployments := kube.AppsV1().Deployments(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return deployments.List(ctx, opts)
+		}
+		watchFn = deployments.Watch
+	case types.ResourceTypeStatefulSet:
+		statefulsets := kube.AppsV1().StatefulSets(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return statefulsets.List(ctx, opts)
+		}
+		watchFn = statefulsets.Watch
+	case types.ResourceTypeDaemonSet:
+		daemonsets := kube.AppsV1().DaemonSets(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return daemonsets.List(ctx, opts)
+		}
+		watchFn = daemonsets.Watch
+	case types.ResourceTypeReplicaSet:
+		replicasets := kube.AppsV1().ReplicaSets(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return replicasets.List(ctx, opts)
+		}
+		watchFn = replicasets.Watch
+	case types.ResourceTypeJob:
+		jobs := kube.BatchV1().Jobs(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return jobs.List(ctx, opts)
+		}
+		watchFn = jobs.Watch
+	case types.ResourceTypeCronJob:
+		cronjobs := kube.BatchV1().CronJobs(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return cronjobs.List(ctx, opts)
+		}
+		watchFn = cronjobs.Watch
+	case types.ResourceTypeConfigMap:
+		configmaps := kube.CoreV1().ConfigMaps(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return configmaps.List(ctx, opts)
+		}
+		watchFn = configmaps.Watch
+	case types.ResourceTypeSecret:
+		secrets := kube.CoreV1().Secrets(namespace)
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return secrets.List(ctx, opts)
+		}
+		watchFn = secrets.Watch
+	case types.ResourceTypeNamespace:
+		namespaces := kube.CoreV1().Namespaces()
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return namespaces.List(ctx, opts)
+		}
+		watchFn = namespaces.Watch
+	case types.ResourceTypeNode:
+		nodes := kube.CoreV1().Nodes()
+		list = func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return nodes.List(ctx, opts)
+		}
+		watchFn = nodes.Watch
+	default:
+		return nil, fmt.Errorf("unsupported resource type for watch: %s", identifier.Type)
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return list(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return watchFn(ctx, opts)
+		},
+	}, nil
+}
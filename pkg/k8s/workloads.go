@@ -0,0 +1,307 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// revisionAnnotation is set by the deployment controller on the replicasets it manages
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+func (c *Client) ListStatefulSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[StatefulSetInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listStatefulSets)
+}
+
+func (c *Client) listStatefulSets(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[StatefulSetInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := kube.AppsV1().StatefulSets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets in namespace %s: %w", namespace, err)
+	}
+
+	var statefulsetInfos []StatefulSetInfo
+	for i := range statefulsets.Items {
+		statefulsetInfos = append(statefulsetInfos, newStatefulSetInfo(&statefulsets.Items[i]))
+	}
+
+	return newListPage(statefulsetInfos, &statefulsets.ListMeta), nil
+}
+
+// GetStatefulSet returns a statefulset with the readiness of each ordinal, its volume
+// claim templates, conditions and recent events
+func (c *Client) GetStatefulSet(ctx context.Context, namespace, name string) (*StatefulSetDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statefulset, err := kube.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, name, err)
+	}
+
+	// Without access to the pods the statefulset is still worth showing, just without
+	// the state of each ordinal
+	ordinals, err := c.getStatefulSetOrdinals(ctx, statefulset)
+	if err != nil {
+		c.logger.WithContext(ctx).Warnf("Failed to get statefulset ordinals: %v", err)
+	}
+
+	var templates []VolumeClaimTemplateInfo
+	for _, claim := range statefulset.Spec.VolumeClaimTemplates {
+		template := VolumeClaimTemplateInfo{Name: claim.Name}
+		if claim.Spec.StorageClassName != nil {
+			template.StorageClass = *claim.Spec.StorageClassName
+		}
+		for _, mode := range claim.Spec.AccessModes {
+			template.AccessModes = append(template.AccessModes, string(mode))
+		}
+		if storage, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			template.Storage = storage.String()
+		}
+		templates = append(templates, template)
+	}
+
+	conditions := trueConditions(statefulset.Status.Conditions, func(condition appsv1.StatefulSetCondition) (string, corev1.ConditionStatus, string) {
+		return string(condition.Type), condition.Status, condition.Message
+	})
+
+	podManagementPolicy := string(statefulset.Spec.PodManagementPolicy)
+	if podManagementPolicy == "" {
+		podManagementPolicy = string(appsv1.OrderedReadyPodManagement)
+	}
+
+	return &StatefulSetDetails{
+		StatefulSetInfo:      newStatefulSetInfo(statefulset),
+		Selector:             matchLabels(statefulset.Spec.Selector),
+		PodManagementPolicy:  podManagementPolicy,
+		CurrentRevision:      statefulset.Status.CurrentRevision,
+		UpdateRevision:       statefulset.Status.UpdateRevision,
+		Ordinals:             ordinals,
+		VolumeClaimTemplates: templates,
+		Conditions:           conditions,
+		Events:               c.recentEventsOrNil(ctx, "StatefulSet", statefulset.Namespace, statefulset.Name),
+	}, nil
+}
+
+// getStatefulSetOrdinals reports the pod of every ordinal the statefulset should have,
+// including ordinals whose pod does not exist (yet)
+func (c *Client) getStatefulSetOrdinals(ctx context.Context, statefulset *appsv1.StatefulSet) ([]StatefulSetOrdinal, error) {
+	selector, err := metav1.LabelSelectorAsSelector(statefulset.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on statefulset %s/%s: %w", statefulset.Namespace, statefulset.Name, err)
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kube.CoreV1().Pods(statefulset.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of statefulset %s/%s: %w", statefulset.Namespace, statefulset.Name, err)
+	}
+
+	podsByName := make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		podsByName[pods.Items[i].Name] = &pods.Items[i]
+	}
+
+	var start int32
+	if statefulset.Spec.Ordinals != nil {
+		start = statefulset.Spec.Ordinals.Start
+	}
+
+	replicas := desiredReplicas(statefulset.Spec.Replicas)
+	ordinals := make([]StatefulSetOrdinal, 0, replicas)
+	for ordinal := start; ordinal < start+replicas; ordinal++ {
+		info := StatefulSetOrdinal{
+			Ordinal: ordinal,
+			Pod:     fmt.Sprintf("%s-%d", statefulset.Name, ordinal),
+		}
+		if pod, ok := podsByName[info.Pod]; ok {
+			info.Phase = string(pod.Status.Phase)
+			info.Ready = isPodReady(pod)
+		}
+		ordinals = append(ordinals, info)
+	}
+
+	return ordinals, nil
+}
+
+func (c *Client) ListDaemonSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[DaemonSetInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listDaemonSets)
+}
+
+func (c *Client) listDaemonSets(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[DaemonSetInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	daemonsets, err := kube.AppsV1().DaemonSets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets in namespace %s: %w", namespace, err)
+	}
+
+	var daemonsetInfos []DaemonSetInfo
+	for i := range daemonsets.Items {
+		daemonsetInfos = append(daemonsetInfos, newDaemonSetInfo(&daemonsets.Items[i]))
+	}
+
+	return newListPage(daemonsetInfos, &daemonsets.ListMeta), nil
+}
+
+// GetDaemonSet returns a daemonset with its selector, conditions and recent events
+func (c *Client) GetDaemonSet(ctx context.Context, namespace, name string) (*DaemonSetDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	daemonset, err := kube.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daemonset %s/%s: %w", namespace, name, err)
+	}
+
+	conditions := trueConditions(daemonset.Status.Conditions, func(condition appsv1.DaemonSetCondition) (string, corev1.ConditionStatus, string) {
+		return string(condition.Type), condition.Status, condition.Message
+	})
+
+	return &DaemonSetDetails{
+		DaemonSetInfo: newDaemonSetInfo(daemonset),
+		Selector:      matchLabels(daemonset.Spec.Selector),
+		Conditions:    conditions,
+		Events:        c.recentEventsOrNil(ctx, "DaemonSet", daemonset.Namespace, daemonset.Name),
+	}, nil
+}
+
+func (c *Client) ListReplicaSets(ctx context.Context, namespace string, opts ListOptions) (*ListPage[ReplicaSetInfo], error) {
+	return listInScope(ctx, c, namespace, opts, c.listReplicaSets)
+}
+
+func (c *Client) listReplicaSets(ctx context.Context, namespace string, listOptions metav1.ListOptions) (*ListPage[ReplicaSetInfo], error) {
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicasets, err := kube.AppsV1().ReplicaSets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets in namespace %s: %w", namespace, err)
+	}
+
+	var replicasetInfos []ReplicaSetInfo
+	for i := range replicasets.Items {
+		replicasetInfos = append(replicasetInfos, newReplicaSetInfo(&replicasets.Items[i]))
+	}
+
+	return newListPage(replicasetInfos, &replicasets.ListMeta), nil
+}
+
+// GetReplicaSet returns a replicaset with its selector, conditions and recent events
+func (c *Client) GetReplicaSet(ctx context.Context, namespace, name string) (*ReplicaSetDetails, error) {
+	if err := c.scope.Check(namespace); err != nil {
+		return nil, err
+	}
+
+	kube, err := c.kube(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicaset, err := kube.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicaset %s/%s: %w", namespace, name, err)
+	}
+
+	conditions := trueConditions(replicaset.Status.Conditions, func(condition appsv1.ReplicaSetCondition) (string, corev1.ConditionStatus, string) {
+		return string(condition.Type), condition.Status, condition.Message
+	})
+
+	return &ReplicaSetDetails{
+		ReplicaSetInfo: newReplicaSetInfo(replicaset),
+		Selector:       matchLabels(replicaset.Spec.Selector),
+		Conditions:     conditions,
+		Events:         c.recentEventsOrNil(ctx, "ReplicaSet", replicaset.Namespace, replicaset.Name),
+	}, nil
+}
+
+func newStatefulSetInfo(statefulset *appsv1.StatefulSet) StatefulSetInfo {
+	info := StatefulSetInfo{
+		Name:            statefulset.Name,
+		Namespace:       statefulset.Namespace,
+		TotalReplicas:   desiredReplicas(statefulset.Spec.Replicas),
+		ReadyReplicas:   statefulset.Status.ReadyReplicas,
+		CurrentReplicas: statefulset.Status.CurrentReplicas,
+		UpdatedReplicas: statefulset.Status.UpdatedReplicas,
+		ServiceName:     statefulset.Spec.ServiceName,
+		UpdateStrategy:  string(statefulset.Spec.UpdateStrategy.Type),
+		Labels:          statefulset.Labels,
+		CreatedAt:       statefulset.CreationTimestamp.Time,
+	}
+	if info.UpdateStrategy == "" {
+		info.UpdateStrategy = string(appsv1.RollingUpdateStatefulSetStrategyType)
+	}
+	if rollingUpdate := statefulset.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		info.Partition = *rollingUpdate.Partition
+	}
+	return info
+}
+
+func newDaemonSetInfo(daemonset *appsv1.DaemonSet) DaemonSetInfo {
+	info := DaemonSetInfo{
+		Name:                   daemonset.Name,
+		Namespace:              daemonset.Namespace,
+		DesiredNumberScheduled: daemonset.Status.DesiredNumberScheduled,
+		CurrentNumberScheduled: daemonset.Status.CurrentNumberScheduled,
+		NumberMisscheduled:     daemonset.Status.NumberMisscheduled,
+		NumberReady:            daemonset.Status.NumberReady,
+		NumberAvailable:        daemonset.Status.NumberAvailable,
+		UpdatedNumberScheduled: daemonset.Status.UpdatedNumberScheduled,
+		UpdateStrategy:         string(daemonset.Spec.UpdateStrategy.Type),
+		NodeSelector:           daemonset.Spec.Template.Spec.NodeSelector,
+		Labels:                 daemonset.Labels,
+		CreatedAt:              daemonset.CreationTimestamp.Time,
+	}
+	if info.UpdateStrategy == "" {
+		info.UpdateStrategy = string(appsv1.RollingUpdateDaemonSetStrategyType)
+	}
+	return info
+}
+
+func newReplicaSetInfo(replicaset *appsv1.ReplicaSet) ReplicaSetInfo {
+	info := ReplicaSetInfo{
+		Name:              replicaset.Name,
+		Namespace:         replicaset.Namespace,
+		TotalReplicas:     desiredReplicas(replicaset.Spec.Replicas),
+		ReadyReplicas:     replicaset.Status.ReadyReplicas,
+		AvailableReplicas: replicaset.Status.AvailableReplicas,
+		Revision:          replicaset.Annotations[revisionAnnotation],
+		Labels:            replicaset.Labels,
+		CreatedAt:         replicaset.CreationTimestamp.Time,
+	}
+	if owner := metav1.GetControllerOf(replicaset); owner != nil && owner.Kind == "Deployment" {
+		info.Owner = owner.Name
+	}
+	return info
+}
+
+// isPodReady reports whether the pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/audit"
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/pkg/auth"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// openAuditLog opens the configured audit log, or returns nil when auditing is disabled
+func openAuditLog(cfg config.AuditConfig) (*audit.Logger, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Path == "" {
+		return nil, errors.New("audit.path must be set when auditing is enabled")
+	}
+
+	file, err := audit.OpenRotatingFile(cfg.Path, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewLogger(file), nil
+}
+
+// startAudit begins the audit trail of an MCP request. Kubernetes calls made with the
+// returned context are recorded under the same correlation ID.
+func (s *Server) startAudit(ctx context.Context) (context.Context, *audit.Trail) {
+	var user string
+	var groups []string
+	if identity, ok := auth.IdentityFrom(ctx); ok {
+		user, groups = identity.User, identity.Groups
+	}
+	return s.audit.Start(ctx, user, groups)
+}
+
+// recordAudit completes an MCP request's audit event with its duration and outcome
+func (s *Server) recordAudit(trail *audit.Trail, event audit.Event, start time.Time, err error) {
+	event.Kind = audit.KindMCP
+	event.DurationMs = audit.Milliseconds(time.Since(start))
+	switch {
+	case err == nil:
+		event.Outcome = audit.OutcomeSuccess
+	case errors.Is(err, ErrRefused):
+		event.Outcome = audit.OutcomeRefused
+		event.Error = err.Error()
+	default:
+		event.Outcome = audit.OutcomeError
+		event.Error = err.Error()
+	}
+
+	if err := trail.Record(event); err != nil {
+		s.logger.Errorf("Failed to write audit record for %s: %v", event.Method, err)
+	}
+}
+
+// auditMiddleware records every tool call, including calls the safety policy refuses
+func (s *Server) auditMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, trail := s.startAudit(ctx)
+		start := time.Now()
+
+		result, err := next(ctx, request)
+
+		outcome := err
+		if err == nil && result != nil && result.IsError {
+			outcome = errors.New(toolResultText(result))
+		}
+		s.recordAudit(trail, audit.Event{
+			Method:    string(mcp.MethodToolsCall),
+			Tool:      request.Params.Name,
+			Arguments: audit.RedactArguments(request.GetArguments()),
+		}, start, outcome)
+
+		return result, err
+	}
+}
+
+// auditResourceRead records every resources/read served by handler
+func (s *Server) auditResourceRead(handler server.ResourceTemplateHandlerFunc) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		ctx, trail := s.startAudit(ctx)
+		start := time.Now()
+
+		contents, err := handler(ctx, request)
+
+		s.recordAudit(trail, audit.Event{
+			Method: string(mcp.MethodResourcesRead),
+			URI:    request.Params.URI,
+		}, start, err)
+
+		return contents, err
+	}
+}
+
+// recordReveal logs and audits that the caller was shown the plaintext of a secret key,
+// separately from the tool call that asked for it
+func (s *Server) recordReveal(ctx context.Context, cluster, namespace, name, key string) {
+	user := "anonymous"
+	if identity, ok := auth.IdentityFrom(ctx); ok {
+		user = identity.User
+	}
+	s.logger.WithContext(ctx).Warnf("Revealed key %q of secret %s/%s in cluster %s to %s", key, namespace, name, cluster, user)
+
+	trail, _ := audit.FromContext(ctx)
+	if err := trail.Record(audit.Event{
+		Kind:      audit.KindMCP,
+		Method:    methodSecretReveal,
+		Cluster:   cluster,
+		Resource:  "secrets",
+		Namespace: namespace,
+		Name:      name,
+		Arguments: map[string]interface{}{"key": key},
+		Outcome:   audit.OutcomeSuccess,
+	}); err != nil {
+		s.logger.Errorf("Failed to write audit record for %s: %v", methodSecretReveal, err)
+	}
+}
+
+// methodSecretReveal marks the audit events of revealed secret values
+const methodSecretReveal = "secrets/reveal"
+
+// toolResultText joins the text content of a tool result, which holds the error message
+// of a failed call
+func toolResultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	if len(texts) == 0 {
+		return "tool returned an error"
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// defaultPageSize is used for list tools and resources/list when the client does not
+// ask for a limit, so a large cluster never produces a single unbounded payload
+const defaultPageSize = 100
+
+// listCursor is the opaque pagination cursor handed to MCP clients. It carries the
+// API server's continue token together with the query it belongs to, so a cursor
+// cannot silently be replayed against a different namespace or selector.
+type listCursor struct {
+	Query    string `json:"q"`
+	Continue string `json:"c"`
+}
+
+// listQuery identifies the list request a cursor was issued for
+func listQuery(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// encodeCursor wraps a continue token into an opaque cursor, or returns "" on the last page
+func encodeCursor(query, continueToken string) string {
+	if continueToken == "" {
+		return ""
+	}
+
+	data, err := json.Marshal(listCursor{Query: query, Continue: continueToken})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor extracts the continue token from a cursor issued for the same query
+func decodeCursor(cursor, query string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return "", fmt.Errorf("invalid cursor: %w", err)
+	}
+	if c.Query != query {
+		return "", fmt.Errorf("cursor was issued for a different query; repeat the original namespace and selectors or start again without a cursor")
+	}
+
+	return c.Continue, nil
+}
@@ -1,223 +1,211 @@
 package mcp
 
 import (
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"onlylight/k8s-mcp-server/pkg/k8s"
 )
 
-type ResourceFormatter struct{}
+// ResourceFormatter renders Kubernetes objects as markdown for LLM clients
+type ResourceFormatter struct {
+	now func() time.Time // clock that ages are measured against
+}
 
 func NewResourceFormatter() *ResourceFormatter {
-	return &ResourceFormatter{}
+	return &ResourceFormatter{now: time.Now}
 }
 
-// FormatPodForAI creates an AI-optimized representation of Pod information
-func (f *ResourceFormatter) FormatPodForAI(podData string) (string, error) {
-	var pod map[string]interface{}
-	if err := json.Unmarshal([]byte(podData), &pod); err != nil {
-		return "", err
+// age formats how long ago t was
+func (f *ResourceFormatter) age(t time.Time) string {
+	return formatDuration(f.now().Sub(t))
+}
+
+// ago formats an optional point in the past, e.g. "5m ago" or "never"
+func (f *ResourceFormatter) ago(t *time.Time) string {
+	if t == nil {
+		return "never"
 	}
+	return f.age(*t) + " ago"
+}
 
+// FormatPodForAI creates an AI-optimized representation of Pod information
+func (f *ResourceFormatter) FormatPodForAI(pod *k8s.PodDetails) string {
 	summary := &strings.Builder{}
 	summary.WriteString("# Pod Summary:\n\n")
 
 	// Basic information
-	summary.WriteString(fmt.Sprintf("**Name**: %s\n", pod["name"]))
-	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", pod["namespace"]))
-	summary.WriteString(fmt.Sprintf("**Status**: %s\n", pod["status"]))
-	summary.WriteString(fmt.Sprintf("**Node**: %s\n", pod["node"]))
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", pod.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", pod.Namespace))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", pod.Status))
+	summary.WriteString(fmt.Sprintf("**Node**: %s\n", pod.Node))
 
-	if restarts, ok := pod["restarts"].(float64); ok && restarts > 0 {
-		summary.WriteString(fmt.Sprintf("**⚠️ Restarts**: %.0f\n", restarts))
+	if pod.Restarts > 0 {
+		summary.WriteString(fmt.Sprintf("**⚠️ Restarts**: %d\n", pod.Restarts))
 	}
 
 	// Creation time
-	if createdAt, ok := pod["createdAt"].(string); ok {
-		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
-			summary.WriteString(fmt.Sprintf("**Created At**: %s\n", formatDuration(time.Since(t))))
-		}
-	}
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(pod.CreatedAt)))
 
 	summary.WriteString("\n## Containers:\n\n")
 
 	// Container Information
-	if containers, ok := pod["containers"].([]interface{}); ok {
-		for _, c := range containers {
-			if c, ok := c.(map[string]interface{}); ok {
-				name := c["name"].(string)
-				image := c["image"].(string)
-				ready := c["ready"].(bool)
-				state := c["state"].(string)
-
-				status := "🟢 Ready"
-				if !ready {
-					status = "🔴 Not Ready"
-				}
-
-				summary.WriteString(fmt.Sprintf("- **%s**: %s\n", name, status))
-				summary.WriteString(fmt.Sprintf("  - Image: %s\n", image))
-				summary.WriteString(fmt.Sprintf("  - State: %s\n", state))
-
-				if restarts, ok := c["restartCount"].(float64); ok && restarts > 0 {
-					summary.WriteString(fmt.Sprintf("  - ⚠️ Restarts: %.0f\n", restarts))
-				}
-			}
+	for _, c := range pod.Containers {
+		status := "🟢 Ready"
+		if !c.Ready {
+			status = "🔴 Not Ready"
+		}
+
+		summary.WriteString(fmt.Sprintf("- **%s**: %s\n", c.Name, status))
+		summary.WriteString(fmt.Sprintf("  - Image: %s\n", c.Image))
+		summary.WriteString(fmt.Sprintf("  - State: %s\n", c.State))
+
+		if c.Restarts > 0 {
+			summary.WriteString(fmt.Sprintf("  - ⚠️ Restarts: %d\n", c.Restarts))
 		}
 	}
 
 	// Condition
-	if conditions, ok := pod["conditions"].([]interface{}); ok && len(conditions) > 0 {
+	if len(pod.Conditions) > 0 {
 		summary.WriteString("\n## Conditions:\n")
-		for _, cond := range conditions {
-			if condStr, ok := cond.(string); ok {
-				summary.WriteString(fmt.Sprintf("- %s\n", condStr))
-			}
+		for _, cond := range pod.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
 		}
 	}
 
+	// Events
+	f.writeEvents(summary, pod.Events)
+
 	// Labels
-	if labels, ok := pod["labels"].(map[string]interface{}); ok && len(labels) > 0 {
-		summary.WriteString("\n## Labels:\n")
-		for k, v := range labels {
-			summary.WriteString(fmt.Sprintf("- %s: %s\n", k, v))
-		}
-	}
+	writeLabels(summary, pod.Labels)
 
 	summary.WriteString("\n---\n")
 	summary.WriteString("*Use this information to understand the pod's current state and troubleshoot any issues.*")
 
-	return summary.String(), nil
+	return summary.String()
 }
 
 // FormatDeploymentForAI creates an AI-optimized view of deployment information
-func (f *ResourceFormatter) FormatDeploymentForAI(deploymentData string) (string, error) {
-	var deployment map[string]interface{}
-	if err := json.Unmarshal([]byte(deploymentData), &deployment); err != nil {
-		return "", err
-	}
-
+func (f *ResourceFormatter) FormatDeploymentForAI(deployment *k8s.DeploymentDetails) string {
 	summary := &strings.Builder{}
 	summary.WriteString("# Deployment Summary:\n\n")
 
 	// Basic information
-	summary.WriteString(fmt.Sprintf("**Name**: %s\n", deployment["name"]))
-	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", deployment["namespace"]))
-	summary.WriteString(fmt.Sprintf("**Strategy**: %s\n", deployment["strategy"]))
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", deployment.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", deployment.Namespace))
+	summary.WriteString(fmt.Sprintf("**Strategy**: %s\n", deployment.Strategy))
 
 	// Replicas Status
-	total := deployment["totalReplicas"].(float64)
-	ready := deployment["readyReplicas"].(float64)
-	updated := deployment["updatedReplicas"].(float64)
+	total := deployment.TotalReplicas
+	ready := deployment.ReadyReplicas
 
-	healthStatus := "🟢 Healthy"
-	if ready < total {
-		healthStatus = "🟠 Scaling"
-	}
-	if ready == 0 {
-		healthStatus = "🔴 Unhealthy"
-	}
-
-	summary.WriteString(fmt.Sprintf("**Status**: %s\n", healthStatus))
-	summary.WriteString(fmt.Sprintf("**Replicas**: %d total, %d ready, %d updated\n", int(total), int(ready), int(updated)))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", replicaHealth(ready, total)))
+	summary.WriteString(fmt.Sprintf("**Replicas**: %d total, %d ready, %d updated\n", total, ready, deployment.UpdatedReplicas))
 
 	// Progress indicator
 	if total > 0 {
-		percentage := (ready / total) * 100
+		percentage := float64(ready) / float64(total) * 100
 		summary.WriteString(fmt.Sprintf("**Progress**: %.0f%%\n", percentage))
 	}
 
 	// Creation time
-	if createdAt, ok := deployment["createdAt"].(string); ok {
-		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
-			age := time.Since(t)
-			summary.WriteString(fmt.Sprintf("**Created At**: %s\n", formatDuration(age)))
-		}
-	}
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(deployment.CreatedAt)))
 
-	// Seletor
-	if selector, ok := deployment["selector"].(map[string]interface{}); ok && len(selector) > 0 {
-		summary.WriteString("\n## Selector:\n")
-		for key, value := range selector {
-			summary.WriteString(fmt.Sprintf("- %s: %s\n", key, value))
-		}
-	}
+	// Selector
+	writeMap(summary, "Selector", "", deployment.Selector)
 
 	// Conditions
-	if conditions, ok := deployment["conditions"].([]interface{}); ok && len(conditions) > 0 {
+	if len(deployment.Conditions) > 0 {
 		summary.WriteString("\n## Conditions:\n")
-		for _, cond := range conditions {
-			if condStr, ok := cond.(string); ok {
-				summary.WriteString(fmt.Sprintf("- %s\n", condStr))
-			}
+		for _, cond := range deployment.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
 		}
 	}
 
+	// Events
+	f.writeEvents(summary, deployment.Events)
+
 	// Recommendations
-	summary.WriteString("\n## AI Assitant Notes\n\n")
+	summary.WriteString("\n## AI Assistant Notes\n\n")
 	if ready < total {
 		summary.WriteString("⚠️ **Action Needed**: Some replicas are not ready. Check pod status and logs.\n")
-	}
-	summary.WriteString("\n---\n")
-	if ready == 0 {
-		summary.WriteString("⚠️ **Action Needed**: Some replicas are not ready. Check pod status and logs.\n")
-	}
-	if ready == total {
+	} else {
 		summary.WriteString("✅ **Status**: Deployment is healthy and all replicas are ready.\n")
 	}
 
-	return summary.String(), nil
+	return summary.String()
 }
 
 // FormatServiceForAI creates an AI-optimized view of service information
-func (f *ResourceFormatter) FormatServiceForAI(serviceData string) (string, error) {
-	var service map[string]interface{}
-	if err := json.Unmarshal([]byte(serviceData), &service); err != nil {
-		return "", err
-	}
-
+func (f *ResourceFormatter) FormatServiceForAI(service *k8s.ServiceDetails) string {
 	summary := &strings.Builder{}
 	summary.WriteString("# Service Summary:\n\n")
 
 	// Basic information
-	summary.WriteString(fmt.Sprintf("**Name**: %s\n", service["name"]))
-	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", service["namespace"]))
-	summary.WriteString(fmt.Sprintf("**Type**: %s\n", service["type"]))
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", service.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", service.Namespace))
+	summary.WriteString(fmt.Sprintf("**Type**: %s\n", service.Type))
 
 	// Cluster IP
-	if clusterIP, ok := service["clusterIP"].(string); ok && clusterIP != "" {
-		summary.WriteString(fmt.Sprintf("**Cluster IP**: %s\n", clusterIP))
+	if service.ClusterIP != "" {
+		summary.WriteString(fmt.Sprintf("**Cluster IP**: %s\n", service.ClusterIP))
 	}
 
 	// Ports
-	if ports, ok := service["ports"].([]interface{}); ok && len(ports) > 0 {
+	if len(service.Ports) > 0 {
 		summary.WriteString("\n## Ports:\n")
-		for _, p := range ports {
-			if portMap, ok := p.(map[string]interface{}); ok {
-				name := ""
-				if n, ok := portMap["name"].(string); ok && n != "" {
-					name = fmt.Sprintf("%s: ", n)
-				}
-				summary.WriteString(fmt.Sprintf("- %sPort %d -> Target Port %d (%s)\n", name, int(portMap["port"].(float64)), int(portMap["targetPort"].(float64)), portMap["protocol"].(string)))
+		for _, port := range service.Ports {
+			name := ""
+			if port.Name != "" {
+				name = fmt.Sprintf("%s: ", port.Name)
 			}
+			summary.WriteString(fmt.Sprintf("- %sPort %d -> Target Port %s (%s)\n", name, port.Port, port.TargetPort, port.Protocol))
 		}
 	}
 
 	// Selector
-	if selector, ok := service["selector"].(map[string]interface{}); ok && len(selector) > 0 {
-		summary.WriteString("\n## Selector:\n")
-		summary.WriteString("This service routes traffic to pods with these labels:\n")
-		for key, value := range selector {
-			summary.WriteString(fmt.Sprintf("- %s: %s\n", key, value))
+	writeMap(summary, "Selector", "This service routes traffic to pods with these labels:\n", service.Selector)
+
+	// Endpoints
+	summary.WriteString("\n## Endpoints:\n")
+	if service.Endpoints == nil {
+		summary.WriteString("*Endpoints could not be read.*\n")
+	} else {
+		summary.WriteString(fmt.Sprintf("**Ready**: %d of %d\n", service.ReadyEndpoints, len(service.Endpoints)))
+	}
+	for _, endpoint := range service.Endpoints {
+		status := "🟢 Ready"
+		if !endpoint.Ready {
+			status = "🔴 Not Ready"
 		}
+
+		line := fmt.Sprintf("- %s: %s", endpoint.Address, status)
+		if endpoint.TargetPod != "" {
+			line += fmt.Sprintf(" (pod %s", endpoint.TargetPod)
+			if endpoint.Node != "" {
+				line += fmt.Sprintf(" on %s", endpoint.Node)
+			}
+			line += ")"
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	if service.MatchingPods != nil && *service.MatchingPods == 0 {
+		summary.WriteString("\n⚠️ **No Matching Pods**: The selector matches no pods in this namespace. Check the selector against the pod labels.\n")
+	} else if service.Endpoints != nil && service.ReadyEndpoints == 0 && service.Type != "ExternalName" {
+		summary.WriteString("\n⚠️ **No Ready Endpoints**: Traffic to this service will fail. Check readiness of the backing pods.\n")
 	}
 
+	// Events
+	f.writeEvents(summary, service.Events)
+
 	// Service Type specific info
-	serviceType := service["type"].(string)
 	summary.WriteString("\n## Connectivity:\n")
 
-	switch serviceType {
+	switch service.Type {
 	case "ClusterIP":
 		summary.WriteString("🔒 **Internal Access Only**: This service is only accessible within the cluster.\n")
 	case "NodePort":
@@ -231,7 +219,1038 @@ func (f *ResourceFormatter) FormatServiceForAI(serviceData string) (string, erro
 	summary.WriteString("\n---\n")
 	summary.WriteString("*Use this information to understand the service's configuration and connectivity.*")
 
-	return summary.String(), nil
+	return summary.String()
+}
+
+// FormatStatefulSetForAI creates an AI-optimized view of a statefulset, with the readiness
+// of every ordinal and its volume claim templates
+func (f *ResourceFormatter) FormatStatefulSetForAI(statefulset *k8s.StatefulSetDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# StatefulSet Summary:\n\n")
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", statefulset.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", statefulset.Namespace))
+	summary.WriteString(fmt.Sprintf("**Service**: %s\n", statefulset.ServiceName))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", replicaHealth(statefulset.ReadyReplicas, statefulset.TotalReplicas)))
+	summary.WriteString(fmt.Sprintf("**Replicas**: %d total, %d ready, %d current, %d updated\n",
+		statefulset.TotalReplicas, statefulset.ReadyReplicas, statefulset.CurrentReplicas, statefulset.UpdatedReplicas))
+
+	strategy := statefulset.UpdateStrategy
+	if statefulset.Partition > 0 {
+		strategy += fmt.Sprintf(" (partition %d)", statefulset.Partition)
+	}
+	summary.WriteString(fmt.Sprintf("**Update Strategy**: %s\n", strategy))
+	summary.WriteString(fmt.Sprintf("**Pod Management**: %s\n", statefulset.PodManagementPolicy))
+
+	rollingOut := statefulset.UpdateRevision != "" && statefulset.UpdateRevision != statefulset.CurrentRevision
+	if rollingOut {
+		summary.WriteString(fmt.Sprintf("**Revision**: %s -> %s (rollout in progress)\n", statefulset.CurrentRevision, statefulset.UpdateRevision))
+	} else if statefulset.CurrentRevision != "" {
+		summary.WriteString(fmt.Sprintf("**Revision**: %s\n", statefulset.CurrentRevision))
+	}
+
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(statefulset.CreatedAt)))
+
+	// Ordinals
+	var firstNotReady *k8s.StatefulSetOrdinal
+	if len(statefulset.Ordinals) > 0 {
+		summary.WriteString("\n## Ordinals:\n")
+		for i, ordinal := range statefulset.Ordinals {
+			switch {
+			case ordinal.Phase == "":
+				summary.WriteString(fmt.Sprintf("- 🔴 **%s**: Missing\n", ordinal.Pod))
+			case ordinal.Ready:
+				summary.WriteString(fmt.Sprintf("- 🟢 **%s**: %s, Ready\n", ordinal.Pod, ordinal.Phase))
+			default:
+				summary.WriteString(fmt.Sprintf("- 🔴 **%s**: %s, Not Ready\n", ordinal.Pod, ordinal.Phase))
+			}
+			if !ordinal.Ready && firstNotReady == nil {
+				firstNotReady = &statefulset.Ordinals[i]
+			}
+		}
+	} else if statefulset.Ordinals == nil && statefulset.TotalReplicas > 0 {
+		summary.WriteString("\n## Ordinals:\n*Pods could not be read.*\n")
+	}
+
+	// Volume claim templates
+	if len(statefulset.VolumeClaimTemplates) > 0 {
+		summary.WriteString("\n## Volume Claim Templates:\n")
+		for _, template := range statefulset.VolumeClaimTemplates {
+			line := fmt.Sprintf("- **%s**: %s", template.Name, template.Storage)
+			if len(template.AccessModes) > 0 {
+				line += ", " + strings.Join(template.AccessModes, ", ")
+			}
+			if template.StorageClass != "" {
+				line += fmt.Sprintf(", storage class %s", template.StorageClass)
+			}
+			summary.WriteString(line + "\n")
+		}
+	}
+
+	// Selector
+	writeMap(summary, "Selector", "", statefulset.Selector)
+
+	// Conditions
+	if len(statefulset.Conditions) > 0 {
+		summary.WriteString("\n## Conditions:\n")
+		for _, cond := range statefulset.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, statefulset.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	healthy := true
+	if firstNotReady != nil {
+		healthy = false
+		last := statefulset.Ordinals[len(statefulset.Ordinals)-1]
+		if statefulset.PodManagementPolicy == "OrderedReady" && firstNotReady.Ordinal != last.Ordinal {
+			summary.WriteString(fmt.Sprintf("⚠️ **Action Needed**: Pod %s is not ready. With OrderedReady pod management, higher ordinals are not created or updated until it is. Check its status and logs.\n", firstNotReady.Pod))
+		} else {
+			summary.WriteString(fmt.Sprintf("⚠️ **Action Needed**: Pod %s is not ready. Check pod status and logs.\n", firstNotReady.Pod))
+		}
+	} else if statefulset.ReadyReplicas < statefulset.TotalReplicas {
+		healthy = false
+		summary.WriteString("⚠️ **Action Needed**: Some replicas are not ready. Check pod status and logs.\n")
+	}
+	if statefulset.Partition > 0 && rollingOut {
+		healthy = false
+		summary.WriteString(fmt.Sprintf("ℹ️ **Partitioned Rollout**: Only ordinals >= %d move to the new revision. Lower ordinals stay on the current revision until the partition is lowered.\n", statefulset.Partition))
+	}
+	if healthy {
+		summary.WriteString("✅ **Status**: StatefulSet is healthy and all ordinals are ready.\n")
+	}
+
+	return summary.String()
+}
+
+// FormatDaemonSetForAI creates an AI-optimized view of a daemonset, explaining pods
+// that are missing, misscheduled or not ready
+func (f *ResourceFormatter) FormatDaemonSetForAI(daemonset *k8s.DaemonSetDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# DaemonSet Summary:\n\n")
+
+	desired := daemonset.DesiredNumberScheduled
+	scheduled := daemonset.CurrentNumberScheduled
+	ready := daemonset.NumberReady
+
+	healthStatus := "🟢 Healthy"
+	if ready < desired || daemonset.NumberMisscheduled > 0 {
+		healthStatus = "🟠 Degraded"
+	}
+	if ready == 0 && desired > 0 {
+		healthStatus = "🔴 Unhealthy"
+	}
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", daemonset.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", daemonset.Namespace))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", healthStatus))
+	summary.WriteString(fmt.Sprintf("**Pods**: %d desired, %d scheduled, %d ready, %d available, %d updated\n",
+		desired, scheduled, ready, daemonset.NumberAvailable, daemonset.UpdatedNumberScheduled))
+	if daemonset.NumberMisscheduled > 0 {
+		summary.WriteString(fmt.Sprintf("**⚠️ Misscheduled**: %d\n", daemonset.NumberMisscheduled))
+	}
+	summary.WriteString(fmt.Sprintf("**Update Strategy**: %s\n", daemonset.UpdateStrategy))
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(daemonset.CreatedAt)))
+
+	// Node selector and pod selector
+	writeMap(summary, "Node Selector", "Pods only run on nodes with these labels:\n", daemonset.NodeSelector)
+	writeMap(summary, "Selector", "", daemonset.Selector)
+
+	// Conditions
+	if len(daemonset.Conditions) > 0 {
+		summary.WriteString("\n## Conditions:\n")
+		for _, cond := range daemonset.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, daemonset.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	healthy := true
+	if desired == 0 {
+		healthy = false
+		summary.WriteString("ℹ️ **No Eligible Nodes**: No node matches the node selector and tolerations, so no pods are scheduled.\n")
+	}
+	if scheduled < desired {
+		healthy = false
+		summary.WriteString(fmt.Sprintf("⚠️ **Not Scheduled**: %d eligible nodes have no daemon pod. Check node taints against the pod's tolerations and the free resources on those nodes.\n", desired-scheduled))
+	}
+	if daemonset.NumberMisscheduled > 0 {
+		healthy = false
+		summary.WriteString(fmt.Sprintf("⚠️ **Misscheduled**: %d pods run on nodes that should not run them. Check recent changes to node labels and taints.\n", daemonset.NumberMisscheduled))
+	}
+	if ready < scheduled {
+		healthy = false
+		summary.WriteString(fmt.Sprintf("⚠️ **Action Needed**: %d scheduled pods are not ready. Check pod status and logs.\n", scheduled-ready))
+	}
+	if healthy {
+		summary.WriteString("✅ **Status**: DaemonSet is healthy and ready on every eligible node.\n")
+	}
+
+	return summary.String()
+}
+
+// FormatReplicaSetForAI creates an AI-optimized view of a replicaset and the deployment
+// revision it belongs to
+func (f *ResourceFormatter) FormatReplicaSetForAI(replicaset *k8s.ReplicaSetDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# ReplicaSet Summary:\n\n")
+
+	total := replicaset.TotalReplicas
+	ready := replicaset.ReadyReplicas
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", replicaset.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", replicaset.Namespace))
+	if replicaset.Owner != "" {
+		owner := fmt.Sprintf("Deployment %s", replicaset.Owner)
+		if replicaset.Revision != "" {
+			owner += fmt.Sprintf(" (revision %s)", replicaset.Revision)
+		}
+		summary.WriteString(fmt.Sprintf("**Owner**: %s\n", owner))
+	}
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", replicaHealth(ready, total)))
+	summary.WriteString(fmt.Sprintf("**Replicas**: %d total, %d ready, %d available\n", total, ready, replicaset.AvailableReplicas))
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(replicaset.CreatedAt)))
+
+	// Selector
+	writeMap(summary, "Selector", "", replicaset.Selector)
+
+	// Conditions
+	replicaFailure := false
+	if len(replicaset.Conditions) > 0 {
+		summary.WriteString("\n## Conditions:\n")
+		for _, cond := range replicaset.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
+			if strings.HasPrefix(cond, "ReplicaFailure:") {
+				replicaFailure = true
+			}
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, replicaset.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	switch {
+	case replicaFailure:
+		summary.WriteString("⚠️ **Replica Failure**: The controller cannot create pods, often because of a resource quota or an admission webhook. Check the condition message and recent events.\n")
+	case ready < total:
+		summary.WriteString("⚠️ **Action Needed**: Some replicas are not ready. Check pod status and logs.\n")
+	case total == 0 && replicaset.Owner != "":
+		summary.WriteString(fmt.Sprintf("ℹ️ **Old Revision**: This replicaset is scaled to zero and kept by deployment %s for rollbacks. Inspect the deployment for the current state.\n", replicaset.Owner))
+	default:
+		summary.WriteString("✅ **Status**: ReplicaSet is healthy and all replicas are ready.\n")
+	}
+
+	return summary.String()
+}
+
+// FormatJobForAI creates an AI-optimized view of a job, explaining why it failed or
+// is still retrying
+func (f *ResourceFormatter) FormatJobForAI(job *k8s.JobDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# Job Summary:\n\n")
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", job.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", job.Namespace))
+	if job.Owner != "" {
+		summary.WriteString(fmt.Sprintf("**Owner**: CronJob %s\n", job.Owner))
+	}
+	summary.WriteString(fmt.Sprintf("**Status**: %s %s\n", jobMarker(job.Status), job.Status))
+	summary.WriteString(fmt.Sprintf("**Completions**: %d/%d succeeded, %d active, %d failed\n", job.Succeeded, job.Completions, job.Active, job.Failed))
+	summary.WriteString(fmt.Sprintf("**Parallelism**: %d\n", job.Parallelism))
+	summary.WriteString(fmt.Sprintf("**Backoff Limit**: %d failed pods allowed, %d used\n", job.BackoffLimit, job.Failed))
+	if job.ActiveDeadlineSeconds != nil {
+		summary.WriteString(fmt.Sprintf("**Active Deadline**: %s\n", formatDuration(time.Duration(*job.ActiveDeadlineSeconds)*time.Second)))
+	}
+	if job.StartTime != nil {
+		end := f.now()
+		if job.CompletionTime != nil {
+			end = *job.CompletionTime
+		}
+		summary.WriteString(fmt.Sprintf("**Started**: %s ago\n", f.age(*job.StartTime)))
+		summary.WriteString(fmt.Sprintf("**Duration**: %s\n", formatDuration(end.Sub(*job.StartTime))))
+	}
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(job.CreatedAt)))
+
+	// Failure
+	if job.FailureReason != "" || job.FailureMessage != "" {
+		summary.WriteString("\n## Failure:\n")
+		summary.WriteString(fmt.Sprintf("**Reason**: %s\n", job.FailureReason))
+		if job.FailureMessage != "" {
+			summary.WriteString(fmt.Sprintf("**Message**: %s\n", job.FailureMessage))
+		}
+	}
+
+	oomKilled := false
+	if len(job.PodFailures) > 0 {
+		summary.WriteString("\n## Failed Containers:\n")
+		for _, failure := range job.PodFailures {
+			line := fmt.Sprintf("- **%s/%s**: %s (exit code %d)", failure.Pod, failure.Container, failure.Reason, failure.ExitCode)
+			if failure.Message != "" {
+				line += ": " + failure.Message
+			}
+			summary.WriteString(line + "\n")
+			if failure.Reason == "OOMKilled" {
+				oomKilled = true
+			}
+		}
+	} else if job.PodFailures == nil && job.Failed > 0 {
+		summary.WriteString("\n## Failed Containers:\n*Pods could not be read.*\n")
+	}
+
+	// Conditions
+	if len(job.Conditions) > 0 {
+		summary.WriteString("\n## Conditions:\n")
+		for _, cond := range job.Conditions {
+			summary.WriteString(fmt.Sprintf("- %s\n", cond))
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, job.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	switch {
+	case job.Status == "Failed" && job.FailureReason == "DeadlineExceeded":
+		summary.WriteString("⚠️ **Deadline Exceeded**: The job ran longer than its active deadline and was stopped. Check whether the workload hangs, or raise activeDeadlineSeconds.\n")
+	case job.Status == "Failed" && job.FailureReason == "BackoffLimitExceeded":
+		summary.WriteString(fmt.Sprintf("⚠️ **Backoff Limit Reached**: %d pods failed, more than the backoff limit of %d allows. Read the logs of the failed containers with get_pod_logs.\n", job.Failed, job.BackoffLimit))
+	case job.Status == "Failed":
+		summary.WriteString("⚠️ **Action Needed**: The job failed. Check the failure reason, the failed containers and recent events.\n")
+	case job.Failed > 0:
+		summary.WriteString(fmt.Sprintf("⚠️ **Retrying**: %d of %d allowed pod failures are used up. Read the logs of the failed containers before the backoff limit is reached.\n", job.Failed, job.BackoffLimit))
+	case job.Status == "Suspended":
+		summary.WriteString("⏸️ **Suspended**: No pods are started until spec.suspend is cleared.\n")
+	case job.Status == "Complete":
+		summary.WriteString("✅ **Status**: Job completed successfully.\n")
+	default:
+		summary.WriteString("ℹ️ **In Progress**: The job has not finished yet.\n")
+	}
+	if oomKilled {
+		summary.WriteString("⚠️ **Out of Memory**: Some containers were OOMKilled. Raise their memory limit or reduce the work per pod.\n")
+	}
+
+	return summary.String()
+}
+
+// FormatCronJobForAI creates an AI-optimized view of a cronjob with its schedule and
+// the outcome of its recent runs, flagging suspension and repeated failures
+func (f *ResourceFormatter) FormatCronJobForAI(cronjob *k8s.CronJobDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# CronJob Summary:\n\n")
+
+	// The controller keeps only failedJobsHistoryLimit failed jobs, so when that many
+	// failed in a row the runs before them are unknown. Only the failures still in the
+	// history count as repeated.
+	historyCapped := cronjob.ConsecutiveFailures > 0 && cronjob.ConsecutiveFailures >= int(cronjob.FailedJobsHistoryLimit)
+	repeatedFailures := cronjob.ConsecutiveFailures > 1
+
+	healthStatus := "🟢 Active"
+	switch {
+	case cronjob.Suspended:
+		healthStatus = "⏸️ Suspended"
+	case cronjob.ScheduleError != "" || repeatedFailures:
+		healthStatus = "🔴 Failing"
+	case cronjob.ConsecutiveFailures == 1:
+		healthStatus = "🟠 Last Run Failed"
+	}
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", cronjob.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", cronjob.Namespace))
+	schedule := fmt.Sprintf("`%s`", cronjob.Schedule)
+	if cronjob.TimeZone != "" {
+		schedule += fmt.Sprintf(" (%s)", cronjob.TimeZone)
+	}
+	summary.WriteString(fmt.Sprintf("**Schedule**: %s\n", schedule))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", healthStatus))
+	summary.WriteString(fmt.Sprintf("**Concurrency Policy**: %s\n", cronjob.ConcurrencyPolicy))
+	summary.WriteString(fmt.Sprintf("**Active Jobs**: %d\n", cronjob.Active))
+	summary.WriteString(fmt.Sprintf("**Last Scheduled**: %s\n", f.ago(cronjob.LastScheduleTime)))
+	summary.WriteString(fmt.Sprintf("**Last Successful**: %s\n", f.ago(cronjob.LastSuccessfulTime)))
+	switch {
+	case cronjob.NextScheduleTime != nil:
+		summary.WriteString(fmt.Sprintf("**Next Run**: in %s (%s)\n", formatDuration(cronjob.NextScheduleTime.Sub(f.now())), cronjob.NextScheduleTime.Format(time.RFC3339)))
+	case cronjob.Suspended:
+		summary.WriteString("**Next Run**: none while suspended\n")
+	}
+	summary.WriteString(fmt.Sprintf("**History Limits**: %d successful, %d failed\n", cronjob.SuccessfulJobsHistoryLimit, cronjob.FailedJobsHistoryLimit))
+	if cronjob.StartingDeadlineSeconds != nil {
+		summary.WriteString(fmt.Sprintf("**Starting Deadline**: %s\n", formatDuration(time.Duration(*cronjob.StartingDeadlineSeconds)*time.Second)))
+	}
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(cronjob.CreatedAt)))
+
+	// Run history
+	if len(cronjob.RecentJobs) > 0 {
+		summary.WriteString(fmt.Sprintf("\n## Recent Jobs (%d):\n", len(cronjob.RecentJobs)))
+		for _, job := range cronjob.RecentJobs {
+			line := fmt.Sprintf("- %s **%s**: %s, %d/%d succeeded", jobMarker(job.Status), job.Name, job.Status, job.Succeeded, job.Completions)
+			if job.Failed > 0 {
+				line += fmt.Sprintf(", %d failed", job.Failed)
+			}
+			summary.WriteString(line + fmt.Sprintf(", age %s\n", f.age(job.CreatedAt)))
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, cronjob.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	healthy := true
+	if cronjob.ScheduleError != "" {
+		healthy = false
+		summary.WriteString(fmt.Sprintf("⚠️ **Invalid Schedule**: %s. The controller cannot schedule any job until the schedule is fixed.\n", cronjob.ScheduleError))
+	}
+	if cronjob.Suspended {
+		healthy = false
+		summary.WriteString("⏸️ **Suspended**: No new jobs are scheduled until spec.suspend is cleared. Jobs that already run are not affected.\n")
+	}
+	if repeatedFailures {
+		healthy = false
+		failed := "The last job failed"
+		if cronjob.ConsecutiveFailures > 1 {
+			failed = fmt.Sprintf("The last %d jobs failed", cronjob.ConsecutiveFailures)
+		}
+		if historyCapped {
+			failed += fmt.Sprintf(" and failedJobsHistoryLimit %d keeps no older failed jobs, so earlier runs may have failed too", cronjob.FailedJobsHistoryLimit)
+		}
+		summary.WriteString(fmt.Sprintf("⚠️ **Repeated Failures**: %s. Read the newest failed job for its failure reason and failed containers.\n", failed))
+	} else if cronjob.ConsecutiveFailures == 1 {
+		healthy = false
+		summary.WriteString("⚠️ **Action Needed**: The last finished job failed. Read it for its failure reason and failed containers.\n")
+		if historyCapped {
+			summary.WriteString(fmt.Sprintf("ℹ️ **History Limit**: failedJobsHistoryLimit %d keeps no older failed jobs, so whether earlier runs failed as well cannot be told from the remaining jobs.\n", cronjob.FailedJobsHistoryLimit))
+		}
+	}
+	if healthy {
+		summary.WriteString("✅ **Status**: CronJob runs on schedule and its last finished job succeeded.\n")
+	}
+
+	return summary.String()
+}
+
+// maxConfigMapValueBytes is how much of each configmap value FormatConfigMapForAI shows
+const maxConfigMapValueBytes = 2048
+
+// FormatConfigMapForAI creates an AI-optimized view of a configmap. Large values are
+// truncated and binaryData is only summarized by size.
+func (f *ResourceFormatter) FormatConfigMapForAI(configmap *k8s.ConfigMapInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# ConfigMap Summary:\n\n")
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", configmap.Name))
+	summary.WriteString(fmt.Sprintf("**Namespace**: %s\n", configmap.Namespace))
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(configmap.CreatedAt)))
+
+	keys := sortedKeys(configmap.Data)
+	summary.WriteString(fmt.Sprintf("\n## Data (%d keys):\n", len(keys)))
+	for _, key := range keys {
+		value, truncated := truncateValue(configmap.Data[key], maxConfigMapValueBytes)
+		summary.WriteString(fmt.Sprintf("\n### %s\n\n```\n%s\n```\n", key, value))
+		if truncated > 0 {
+			summary.WriteString(fmt.Sprintf("*%d more bytes not shown*\n", truncated))
+		}
+	}
+
+	if len(configmap.BinaryData) > 0 {
+		binaryKeys := sortedKeys(configmap.BinaryData)
+		summary.WriteString(fmt.Sprintf("\n## Binary Data (%d keys):\n", len(binaryKeys)))
+		for _, key := range binaryKeys {
+			summary.WriteString(fmt.Sprintf("- %s: %d bytes\n", key, configmap.BinaryData[key]))
+		}
+	}
+
+	writeLabels(summary, configmap.Labels)
+
+	return summary.String()
+}
+
+// FormatNamespaceForAI creates an AI-optimized view of a namespace
+func (f *ResourceFormatter) FormatNamespaceForAI(namespace *k8s.NamespaceInfo) string {
+	status := "🟢 " + namespace.Status
+	if namespace.Status != "Active" {
+		status = "🟠 " + namespace.Status
+	}
+
+	summary := &strings.Builder{}
+	summary.WriteString("# Namespace Summary:\n\n")
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", namespace.Name))
+	summary.WriteString(fmt.Sprintf("**Status**: %s\n", status))
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(namespace.CreatedAt)))
+
+	writeLabels(summary, namespace.Labels)
+
+	if namespace.Status == "Terminating" {
+		summary.WriteString("\n⚠️ **Action Needed**: The namespace is being deleted. Check for finalizers that block it.\n")
+	}
+
+	return summary.String()
+}
+
+// nodePressureNotes explains the node conditions that k8s.NodeInfo reports as pressures
+var nodePressureNotes = map[string]string{
+	"MemoryPressure":     "⚠️ **Memory Pressure**: The kubelet is evicting pods to reclaim memory, starting with pods that use more than they request.",
+	"DiskPressure":       "⚠️ **Disk Pressure**: The kubelet is removing unused images and evicting pods to free disk space.",
+	"PIDPressure":        "⚠️ **PID Pressure**: Processes on the node are close to the PID limit and new ones may fail to start.",
+	"NetworkUnavailable": "⚠️ **Network Unavailable**: The node's network is not configured, so its pods cannot reach the network.",
+}
+
+// nearlyFullPercent is the share of allocatable cpu or memory requested by pods above
+// which a node is reported as nearly full
+const nearlyFullPercent = 90
+
+// FormatNodeForAI creates an AI-optimized view of a node, comparing what its pods request
+// with what it can allocate and highlighting pressure, cordons and taints
+func (f *ResourceFormatter) FormatNodeForAI(node *k8s.NodeDetails) string {
+	summary := &strings.Builder{}
+	summary.WriteString("# Node Summary:\n\n")
+
+	// Basic information
+	summary.WriteString(fmt.Sprintf("**Name**: %s\n", node.Name))
+	summary.WriteString(fmt.Sprintf("**Status**: %s %s\n", nodeMarker(node.NodeInfo), node.Status))
+	if node.Unschedulable {
+		summary.WriteString("**Schedulable**: ⛔ No (cordoned)\n")
+	} else {
+		summary.WriteString("**Schedulable**: Yes\n")
+	}
+	if len(node.Roles) > 0 {
+		summary.WriteString(fmt.Sprintf("**Roles**: %s\n", strings.Join(node.Roles, ", ")))
+	}
+	summary.WriteString(fmt.Sprintf("**Kubelet Version**: %s\n", node.KubeletVersion))
+	summary.WriteString(fmt.Sprintf("**OS**: %s (%s/%s)\n", node.OSImage, node.OS, node.Architecture))
+	if node.InternalIP != "" {
+		summary.WriteString(fmt.Sprintf("**Internal IP**: %s\n", node.InternalIP))
+	}
+	summary.WriteString(fmt.Sprintf("**Created At**: %s\n", f.age(node.CreatedAt)))
+
+	// Capacity
+	summary.WriteString("\n## Capacity / Allocatable:\n")
+	summary.WriteString(fmt.Sprintf("- **cpu**: %s / %s\n", node.Capacity.CPU, node.Allocatable.CPU))
+	summary.WriteString(fmt.Sprintf("- **memory**: %s / %s\n", node.Capacity.Memory, node.Allocatable.Memory))
+	summary.WriteString(fmt.Sprintf("- **pods**: %s / %s\n", node.Capacity.Pods, node.Allocatable.Pods))
+	if node.Capacity.EphemeralStorage != "" {
+		summary.WriteString(fmt.Sprintf("- **ephemeral-storage**: %s / %s\n", node.Capacity.EphemeralStorage, node.Allocatable.EphemeralStorage))
+	}
+
+	// Allocation
+	summary.WriteString("\n## Allocated Resources:\n")
+	if node.Pods == nil {
+		summary.WriteString("*Pods on this node could not be read.*\n")
+	} else {
+		if node.PodsInScopeOnly {
+			summary.WriteString("*Only pods in the server's namespace scope are counted.*\n")
+		}
+		for _, allocation := range node.Allocated {
+			summary.WriteString(fmt.Sprintf("- **%s**: %s requested (%d%%), %s limits (%d%%) of %s allocatable\n",
+				allocation.Resource, allocation.Requests, allocation.RequestsPercent, allocation.Limits, allocation.LimitsPercent, allocation.Allocatable))
+		}
+		summary.WriteString(fmt.Sprintf("- **pods**: %d of %s\n", len(node.Pods), node.Allocatable.Pods))
+	}
+
+	// Conditions
+	if len(node.Conditions) > 0 {
+		summary.WriteString("\n## Conditions:\n")
+		for _, cond := range node.Conditions {
+			marker := "✅"
+			if (cond.Type == "Ready") != (cond.Status == "True") {
+				marker = "⚠️"
+			}
+			line := fmt.Sprintf("- %s **%s**: %s", marker, cond.Type, cond.Status)
+			if cond.Reason != "" {
+				line += fmt.Sprintf(" (%s)", cond.Reason)
+			}
+			if cond.Message != "" {
+				line += ": " + cond.Message
+			}
+			summary.WriteString(line + "\n")
+		}
+	}
+
+	// Taints
+	if len(node.Taints) > 0 {
+		summary.WriteString("\n## Taints:\n")
+		for _, taint := range node.Taints {
+			summary.WriteString(fmt.Sprintf("- %s\n", taint))
+		}
+	}
+
+	// Pods
+	if len(node.Pods) > 0 {
+		summary.WriteString(fmt.Sprintf("\n## Pods (%d):\n", len(node.Pods)))
+		for _, pod := range node.Pods {
+			summary.WriteString(fmt.Sprintf("- **%s/%s**: %s, requests cpu %s, memory %s\n", pod.Namespace, pod.Name, pod.Phase, pod.CPURequests, pod.MemoryRequests))
+		}
+	}
+
+	// Events
+	f.writeEvents(summary, node.Events)
+
+	// Recommendations
+	summary.WriteString("\n## AI Assistant Notes\n\n")
+	healthy := true
+	switch node.Status {
+	case "NotReady":
+		healthy = false
+		summary.WriteString("⚠️ **Node Not Ready**: The kubelet reports the node as not ready. Its pods are evicted once their toleration runs out; check the Ready condition and the kubelet on the node.\n")
+	case "Unknown":
+		healthy = false
+		summary.WriteString("⚠️ **Node Unreachable**: The kubelet stopped reporting status. The node may be down or cut off from the API server.\n")
+	}
+	for _, pressure := range node.Pressures {
+		healthy = false
+		summary.WriteString(nodePressureNotes[pressure] + "\n")
+	}
+	if node.Unschedulable {
+		healthy = false
+		summary.WriteString("⛔ **Cordoned**: No new pods are scheduled on this node. Uncordon it once maintenance is done.\n")
+	}
+	for _, allocation := range node.Allocated {
+		if allocation.RequestsPercent >= nearlyFullPercent {
+			healthy = false
+			summary.WriteString(fmt.Sprintf("⚠️ **Nearly Full**: Pods request %d%% of the allocatable %s, so new pods that request %s may not fit here.\n", allocation.RequestsPercent, allocation.Resource, allocation.Resource))
+		}
+		if allocation.Resource == "memory" && allocation.LimitsPercent > 100 {
+			summary.WriteString(fmt.Sprintf("ℹ️ **Overcommitted**: Memory limits add up to %d%% of allocatable memory. If pods use their limits the node runs out of memory.\n", allocation.LimitsPercent))
+		}
+	}
+	if healthy {
+		summary.WriteString("✅ **Status**: Node is ready, schedulable and under no pressure.\n")
+	}
+
+	return summary.String()
+}
+
+// FormatPodListForAI creates an AI-optimized overview of a list of pods
+func (f *ResourceFormatter) FormatPodListForAI(pods []k8s.PodInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Pods (%d):\n\n", len(pods)))
+
+	for _, pod := range pods {
+		status := "🟢"
+		if pod.Phase != "Running" && pod.Phase != "Succeeded" {
+			status = "🔴"
+		}
+		summary.WriteString(fmt.Sprintf("- %s **%s/%s**: %s on %s, age %s", status, pod.Namespace, pod.Name, pod.Phase, pod.Node, f.age(pod.CreatedAt)))
+		if pod.Restarts > 0 {
+			summary.WriteString(fmt.Sprintf(", ⚠️ %d restarts", pod.Restarts))
+		}
+		summary.WriteString("\n")
+	}
+
+	return summary.String()
+}
+
+// FormatServiceListForAI creates an AI-optimized overview of a list of services
+func (f *ResourceFormatter) FormatServiceListForAI(services []k8s.ServiceInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Services (%d):\n\n", len(services)))
+
+	for _, svc := range services {
+		var ports []string
+		for _, port := range svc.Ports {
+			ports = append(ports, fmt.Sprintf("%d->%s/%s", port.Port, port.TargetPort, port.Protocol))
+		}
+		summary.WriteString(fmt.Sprintf("- **%s/%s**: %s %s [%s]\n", svc.Namespace, svc.Name, svc.Type, svc.ClusterIP, strings.Join(ports, ", ")))
+	}
+
+	return summary.String()
+}
+
+// FormatDeploymentListForAI creates an AI-optimized overview of a list of deployments
+func (f *ResourceFormatter) FormatDeploymentListForAI(deployments []k8s.DeploymentInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Deployments (%d):\n\n", len(deployments)))
+
+	for _, deploy := range deployments {
+		summary.WriteString(fmt.Sprintf("- %s **%s/%s**: %d/%d ready, %d updated (%s)\n", replicaMarker(deploy.ReadyReplicas, deploy.TotalReplicas), deploy.Namespace, deploy.Name, deploy.ReadyReplicas, deploy.TotalReplicas, deploy.UpdatedReplicas, deploy.Strategy))
+	}
+
+	return summary.String()
+}
+
+// FormatStatefulSetListForAI creates an AI-optimized overview of a list of statefulsets
+func (f *ResourceFormatter) FormatStatefulSetListForAI(statefulsets []k8s.StatefulSetInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# StatefulSets (%d):\n\n", len(statefulsets)))
+
+	for _, set := range statefulsets {
+		line := fmt.Sprintf("- %s **%s/%s**: %d/%d ready, %d updated, service %s (%s",
+			replicaMarker(set.ReadyReplicas, set.TotalReplicas), set.Namespace, set.Name, set.ReadyReplicas, set.TotalReplicas, set.UpdatedReplicas, set.ServiceName, set.UpdateStrategy)
+		if set.Partition > 0 {
+			line += fmt.Sprintf(", partition %d", set.Partition)
+		}
+		summary.WriteString(line + ")\n")
+	}
+
+	return summary.String()
+}
+
+// FormatDaemonSetListForAI creates an AI-optimized overview of a list of daemonsets
+func (f *ResourceFormatter) FormatDaemonSetListForAI(daemonsets []k8s.DaemonSetInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# DaemonSets (%d):\n\n", len(daemonsets)))
+
+	for _, daemon := range daemonsets {
+		status := replicaMarker(daemon.NumberReady, daemon.DesiredNumberScheduled)
+		if status == "🟢" && daemon.NumberMisscheduled > 0 {
+			status = "🟠"
+		}
+		line := fmt.Sprintf("- %s **%s/%s**: %d desired, %d scheduled, %d ready, %d available",
+			status, daemon.Namespace, daemon.Name, daemon.DesiredNumberScheduled, daemon.CurrentNumberScheduled, daemon.NumberReady, daemon.NumberAvailable)
+		if daemon.NumberMisscheduled > 0 {
+			line += fmt.Sprintf(", ⚠️ %d misscheduled", daemon.NumberMisscheduled)
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	return summary.String()
+}
+
+// FormatReplicaSetListForAI creates an AI-optimized overview of a list of replicasets
+func (f *ResourceFormatter) FormatReplicaSetListForAI(replicasets []k8s.ReplicaSetInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# ReplicaSets (%d):\n\n", len(replicasets)))
+
+	for _, rs := range replicasets {
+		line := fmt.Sprintf("- %s **%s/%s**: %d/%d ready", replicaMarker(rs.ReadyReplicas, rs.TotalReplicas), rs.Namespace, rs.Name, rs.ReadyReplicas, rs.TotalReplicas)
+		if rs.Owner != "" {
+			line += fmt.Sprintf(", deployment %s", rs.Owner)
+			if rs.Revision != "" {
+				line += fmt.Sprintf(" revision %s", rs.Revision)
+			}
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	return summary.String()
+}
+
+// FormatJobListForAI creates an AI-optimized overview of a list of jobs
+func (f *ResourceFormatter) FormatJobListForAI(jobs []k8s.JobInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Jobs (%d):\n\n", len(jobs)))
+
+	for _, job := range jobs {
+		line := fmt.Sprintf("- %s **%s/%s**: %s, %d/%d succeeded", jobMarker(job.Status), job.Namespace, job.Name, job.Status, job.Succeeded, job.Completions)
+		if job.Failed > 0 {
+			line += fmt.Sprintf(", ⚠️ %d failed", job.Failed)
+		}
+		line += fmt.Sprintf(", age %s", f.age(job.CreatedAt))
+		if job.Owner != "" {
+			line += fmt.Sprintf(", cronjob %s", job.Owner)
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	return summary.String()
+}
+
+// FormatCronJobListForAI creates an AI-optimized overview of a list of cronjobs
+func (f *ResourceFormatter) FormatCronJobListForAI(cronjobs []k8s.CronJobInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# CronJobs (%d):\n\n", len(cronjobs)))
+
+	for _, cronjob := range cronjobs {
+		status := "🟢"
+		if cronjob.Suspended {
+			status = "⏸️"
+		} else if cronjob.ScheduleError != "" {
+			status = "🔴"
+		}
+		line := fmt.Sprintf("- %s **%s/%s**: `%s`, last run %s", status, cronjob.Namespace, cronjob.Name, cronjob.Schedule, f.ago(cronjob.LastScheduleTime))
+		switch {
+		case cronjob.Suspended:
+			line += ", suspended"
+		case cronjob.NextScheduleTime != nil:
+			line += fmt.Sprintf(", next in %s", formatDuration(cronjob.NextScheduleTime.Sub(f.now())))
+		}
+		if cronjob.Active > 0 {
+			line += fmt.Sprintf(", %d active", cronjob.Active)
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	return summary.String()
+}
+
+// FormatConfigMapListForAI creates an AI-optimized overview of a list of configmaps
+func (f *ResourceFormatter) FormatConfigMapListForAI(configmaps []k8s.ConfigMapInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# ConfigMaps (%d):\n\n", len(configmaps)))
+
+	for _, cm := range configmaps {
+		keys := sortedKeys(cm.Data)
+		summary.WriteString(fmt.Sprintf("- **%s/%s**: %d keys [%s]\n", cm.Namespace, cm.Name, len(keys), strings.Join(keys, ", ")))
+	}
+
+	return summary.String()
+}
+
+// FormatSecretListForAI creates an AI-optimized overview of a list of secrets, without their values
+func (f *ResourceFormatter) FormatSecretListForAI(secrets []k8s.SecretInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Secrets (%d):\n\n", len(secrets)))
+
+	for _, secret := range secrets {
+		keys := make([]string, 0, len(secret.Keys))
+		for _, key := range secret.Keys {
+			keys = append(keys, key.Key)
+		}
+		summary.WriteString(fmt.Sprintf("- **%s/%s** (%s): %d keys [%s]\n", secret.Namespace, secret.Name, secret.Type, len(keys), strings.Join(keys, ", ")))
+	}
+
+	return summary.String()
+}
+
+// FormatSecretForAI creates an AI-optimized view of a secret. Values appear only for
+// keys that were explicitly revealed.
+func (f *ResourceFormatter) FormatSecretForAI(secret *k8s.SecretInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Secret: %s/%s\n\n", secret.Namespace, secret.Name))
+	summary.WriteString(fmt.Sprintf("**Type:** %s\n", secret.Type))
+	summary.WriteString(fmt.Sprintf("**Age:** %s\n\n", f.age(secret.CreatedAt)))
+
+	summary.WriteString(fmt.Sprintf("## Keys (%d)\n", len(secret.Keys)))
+	for _, key := range secret.Keys {
+		summary.WriteString(fmt.Sprintf("- **%s**: %d bytes, sha256 `%s`\n", key.Key, key.Size, key.SHA256))
+		if key.Revealed {
+			summary.WriteString(fmt.Sprintf("  ```\n  %s\n  ```\n", strings.ReplaceAll(key.Value, "\n", "\n  ")))
+		}
+	}
+
+	return summary.String()
+}
+
+// FormatNamespaceListForAI creates an AI-optimized overview of a list of namespaces
+func (f *ResourceFormatter) FormatNamespaceListForAI(namespaces []k8s.NamespaceInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Namespaces (%d):\n\n", len(namespaces)))
+
+	for _, ns := range namespaces {
+		summary.WriteString(fmt.Sprintf("- **%s**: %s, age %s\n", ns.Name, ns.Status, f.age(ns.CreatedAt)))
+	}
+
+	return summary.String()
+}
+
+// FormatNodeListForAI creates an AI-optimized overview of a list of nodes
+func (f *ResourceFormatter) FormatNodeListForAI(nodes []k8s.NodeInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Nodes (%d):\n\n", len(nodes)))
+
+	for _, node := range nodes {
+		line := fmt.Sprintf("- %s **%s**: %s", nodeMarker(node), node.Name, node.Status)
+		if len(node.Roles) > 0 {
+			line += ", " + strings.Join(node.Roles, ", ")
+		}
+		line += fmt.Sprintf(", %s, %s/%s, age %s", node.KubeletVersion, node.OS, node.Architecture, f.age(node.CreatedAt))
+		if node.Unschedulable {
+			line += ", ⛔ cordoned"
+		}
+		if len(node.Pressures) > 0 {
+			line += ", ⚠️ " + strings.Join(node.Pressures, ", ")
+		}
+		if len(node.Taints) > 0 {
+			line += fmt.Sprintf(", %d taints", len(node.Taints))
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	return summary.String()
+}
+
+// FormatPodLogsForAI wraps container logs in markdown with the context needed to read them
+func (f *ResourceFormatter) FormatPodLogsForAI(logs *k8s.PodLogs) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Logs: %s/%s (container %s)\n\n", logs.Namespace, logs.Pod, logs.Container))
+
+	if logs.Previous {
+		summary.WriteString("*Showing logs of the previous container instance.*\n")
+	}
+	if logs.Truncated {
+		summary.WriteString("⚠️ **Truncated**: Older lines were dropped to fit the size limit. Narrow the query with grep, tailLines or sinceSeconds.\n")
+	}
+	if logs.LinesCut {
+		summary.WriteString("⚠️ **Lines Cut**: At least one line was longer than the size limit and was cut short; its end is missing.\n")
+	}
+	summary.WriteString(fmt.Sprintf("**Lines**: %d\n\n", logs.Lines))
+
+	if logs.Lines == 0 {
+		summary.WriteString("*No log lines matched.*\n")
+		return summary.String()
+	}
+
+	summary.WriteString("```\n")
+	summary.WriteString(logs.Logs)
+	summary.WriteString("\n```\n")
+
+	return summary.String()
+}
+
+// replicaMarker summarizes how many of the desired replicas are ready as a status emoji
+func replicaMarker(ready, total int32) string {
+	switch {
+	case ready == 0 && total > 0:
+		return "🔴"
+	case ready < total:
+		return "🟠"
+	}
+	return "🟢"
+}
+
+// replicaHealth is replicaMarker with a label, for detail views
+func replicaHealth(ready, total int32) string {
+	switch marker := replicaMarker(ready, total); marker {
+	case "🔴":
+		return marker + " Unhealthy"
+	case "🟠":
+		return marker + " Scaling"
+	default:
+		return marker + " Healthy"
+	}
+}
+
+// jobMarker is the status emoji of a job status as reported by k8s.JobInfo
+func jobMarker(status string) string {
+	switch status {
+	case "Complete", "Running":
+		return "🟢"
+	case "Failed":
+		return "🔴"
+	case "Suspended":
+		return "⏸️"
+	default:
+		return "🟠"
+	}
+}
+
+// nodeMarker is the status emoji of a node: red when it is not ready, orange when it is
+// cordoned or under pressure
+func nodeMarker(node k8s.NodeInfo) string {
+	switch {
+	case node.Status != "Ready":
+		return "🔴"
+	case node.Unschedulable || len(node.Pressures) > 0:
+		return "🟠"
+	}
+	return "🟢"
+}
+
+// writeLabels appends a sorted labels section, if there are any labels
+func writeLabels(summary *strings.Builder, labels map[string]string) {
+	writeMap(summary, "Labels", "", labels)
+}
+
+// writeMap appends a section listing the entries of m in key order, if m is not empty
+func writeMap(summary *strings.Builder, title, intro string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+
+	summary.WriteString(fmt.Sprintf("\n## %s:\n", title))
+	summary.WriteString(intro)
+	for _, key := range sortedKeys(m) {
+		summary.WriteString(fmt.Sprintf("- %s: %s\n", key, m[key]))
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order, so rendered output is stable
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// truncateValue cuts s to at most maxBytes without splitting a UTF-8 character and
+// returns how many bytes were dropped
+func truncateValue(s string, maxBytes int) (string, int) {
+	if len(s) <= maxBytes {
+		return s, 0
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut], len(s) - cut
+}
+
+// writeEvents renders recent events about an object, flagging warnings
+func (f *ResourceFormatter) writeEvents(summary *strings.Builder, events []k8s.EventInfo) {
+	if len(events) == 0 {
+		return
+	}
+
+	summary.WriteString("\n## Recent Events:\n")
+	for _, event := range events {
+		marker := "-"
+		if event.Type == "Warning" {
+			marker = "- ⚠️"
+		}
+
+		age := ""
+		if !event.LastSeen.IsZero() {
+			age = fmt.Sprintf(" (%s ago)", f.age(event.LastSeen))
+		}
+
+		count := ""
+		if event.Count > 1 {
+			count = fmt.Sprintf(" x%d", event.Count)
+		}
+
+		summary.WriteString(fmt.Sprintf("%s **%s**%s%s: %s\n", marker, event.Reason, count, age, event.Message))
+	}
+}
+
+// FormatContextListForAI creates an AI-optimized overview of the served clusters and the
+// kubeconfig contexts behind them
+func (f *ResourceFormatter) FormatContextListForAI(contexts []k8s.ContextInfo, clusters []k8s.ClusterInfo) string {
+	summary := &strings.Builder{}
+	summary.WriteString(fmt.Sprintf("# Clusters (%d):\n\n", len(clusters)))
+
+	byName := make(map[string]k8s.ContextInfo)
+	for _, c := range contexts {
+		byName[c.Name] = c
+	}
+	for _, c := range clusters {
+		marker := "-"
+		if c.Default {
+			marker = "- 👉"
+		}
+		line := fmt.Sprintf("%s **%s**: context %s", marker, c.Name, c.Context)
+		if kubeconfigContext, ok := byName[c.Context]; ok {
+			line += fmt.Sprintf(" (cluster %s, user %s", kubeconfigContext.Cluster, kubeconfigContext.User)
+			if kubeconfigContext.Namespace != "" {
+				line += fmt.Sprintf(", namespace %s", kubeconfigContext.Namespace)
+			}
+			line += ")"
+		}
+		if c.Default {
+			line += " (default)"
+		}
+		summary.WriteString(line + "\n")
+	}
+
+	summary.WriteString("\n*Only these clusters can be passed as cluster or selected with use_context.*\n")
+
+	return summary.String()
 }
 
 // Helper function to format duration in a human-readable way
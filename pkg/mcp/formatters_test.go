@@ -0,0 +1,1233 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"onlylight/k8s-mcp-server/pkg/k8s"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestFormatter() *ResourceFormatter {
+	return &ResourceFormatter{now: func() time.Time { return testNow }}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// assertRendered checks that every want fragment appears in got and no notWant fragment does
+func assertRendered(t *testing.T, got string, want, notWant []string) {
+	t.Helper()
+	for _, fragment := range want {
+		if !strings.Contains(got, fragment) {
+			t.Errorf("output is missing %q:\n%s", fragment, got)
+		}
+	}
+	for _, fragment := range notWant {
+		if strings.Contains(got, fragment) {
+			t.Errorf("output unexpectedly contains %q:\n%s", fragment, got)
+		}
+	}
+}
+
+func TestFormatPodForAI(t *testing.T) {
+	base := k8s.PodInfo{
+		Name:      "web-0",
+		Namespace: "shop",
+		Status:    "Running",
+		Phase:     "Running",
+		Node:      "node-1",
+		CreatedAt: testNow.Add(-2 * time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		pod     k8s.PodDetails
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "healthy pod",
+			pod: k8s.PodDetails{
+				PodInfo: base,
+				Containers: []k8s.ContainerInfo{
+					{Name: "app", Image: "shop/web:1.2", Ready: true, State: "Running"},
+				},
+				Conditions: []string{"Ready", "PodScheduled"},
+			},
+			want: []string{
+				"**Name**: web-0\n",
+				"**Namespace**: shop\n",
+				"**Status**: Running\n",
+				"**Node**: node-1\n",
+				"**Created At**: 2.0h\n",
+				"- **app**: 🟢 Ready\n",
+				"  - Image: shop/web:1.2\n",
+				"  - State: Running\n",
+				"## Conditions:\n- Ready\n- PodScheduled\n",
+			},
+			notWant: []string{"Restarts", "## Recent Events", "## Labels"},
+		},
+		{
+			name: "restarts are reported for the pod and each container",
+			pod: k8s.PodDetails{
+				PodInfo: func() k8s.PodInfo { p := base; p.Restarts = 7; return p }(),
+				Containers: []k8s.ContainerInfo{
+					{Name: "app", Image: "shop/web:1.2", Ready: false, Restarts: 5, State: "Waiting: CrashLoopBackOff"},
+					{Name: "sidecar", Image: "envoy:1.30", Ready: true, Restarts: 2, State: "Running"},
+				},
+			},
+			want: []string{
+				"**⚠️ Restarts**: 7\n",
+				"- **app**: 🔴 Not Ready\n",
+				"  - State: Waiting: CrashLoopBackOff\n  - ⚠️ Restarts: 5\n",
+				"- **sidecar**: 🟢 Ready\n",
+				"  - ⚠️ Restarts: 2\n",
+			},
+			notWant: []string{"## Conditions"},
+		},
+		{
+			name: "events and sorted labels",
+			pod: k8s.PodDetails{
+				PodInfo: func() k8s.PodInfo {
+					p := base
+					p.Labels = map[string]string{"tier": "frontend", "app": "web"}
+					return p
+				}(),
+				Events: []k8s.EventInfo{
+					{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 4, LastSeen: testNow.Add(-30 * time.Second)},
+					{Type: "Normal", Reason: "Pulled", Message: "Container image pulled", Count: 1, LastSeen: testNow.Add(-5 * time.Minute)},
+				},
+			},
+			want: []string{
+				"## Recent Events:\n",
+				"- ⚠️ **BackOff** x4 (30s ago): Back-off restarting failed container\n",
+				"- **Pulled** (5m ago): Container image pulled\n",
+				"## Labels:\n- app: web\n- tier: frontend\n",
+			},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatPodForAI(&tt.pod), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatServiceForAI(t *testing.T) {
+	tests := []struct {
+		name    string
+		service k8s.ServiceDetails
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "named target port and ready endpoints",
+			service: k8s.ServiceDetails{
+				ServiceInfo: k8s.ServiceInfo{
+					Name:      "web",
+					Namespace: "shop",
+					Type:      "ClusterIP",
+					ClusterIP: "10.0.0.12",
+					Ports: []k8s.ServicePort{
+						{Name: "http", Port: 80, TargetPort: "http-web", Protocol: "TCP"},
+						{Port: 9090, TargetPort: "9090", Protocol: "TCP"},
+					},
+				},
+				Selector: map[string]string{"tier": "frontend", "app": "web"},
+				Endpoints: []k8s.EndpointInfo{
+					{Address: "10.1.0.4", Ready: true, TargetPod: "web-0", Node: "node-1"},
+					{Address: "10.1.0.5", Ready: false, TargetPod: "web-1"},
+					{Address: "10.1.0.6", Ready: true},
+				},
+				ReadyEndpoints: 2,
+				MatchingPods:   intPtr(2),
+			},
+			want: []string{
+				"**Name**: web\n",
+				"**Namespace**: shop\n",
+				"**Type**: ClusterIP\n",
+				"**Cluster IP**: 10.0.0.12\n",
+				"- http: Port 80 -> Target Port http-web (TCP)\n",
+				"- Port 9090 -> Target Port 9090 (TCP)\n",
+				"## Selector:\nThis service routes traffic to pods with these labels:\n- app: web\n- tier: frontend\n",
+				"**Ready**: 2 of 3\n",
+				"- 10.1.0.4: 🟢 Ready (pod web-0 on node-1)\n",
+				"- 10.1.0.5: 🔴 Not Ready (pod web-1)\n",
+				"- 10.1.0.6: 🟢 Ready\n",
+				"🔒 **Internal Access Only**",
+			},
+			notWant: []string{"No Matching Pods", "No Ready Endpoints"},
+		},
+		{
+			name: "selector matching no pods",
+			service: k8s.ServiceDetails{
+				ServiceInfo:  k8s.ServiceInfo{Name: "api", Namespace: "shop", Type: "NodePort"},
+				Selector:     map[string]string{"app": "api"},
+				Endpoints:    []k8s.EndpointInfo{},
+				MatchingPods: intPtr(0),
+			},
+			want:    []string{"**Ready**: 0 of 0\n", "⚠️ **No Matching Pods**", "🌐 **External Access**"},
+			notWant: []string{"No Ready Endpoints", "**Cluster IP**", "## Ports"},
+		},
+		{
+			name: "endpoints that could not be read",
+			service: k8s.ServiceDetails{
+				ServiceInfo: k8s.ServiceInfo{Name: "api", Namespace: "shop", Type: "ClusterIP"},
+				Selector:    map[string]string{"app": "api"},
+			},
+			want:    []string{"*Endpoints could not be read.*\n"},
+			notWant: []string{"**Ready**", "No Ready Endpoints", "No Matching Pods"},
+		},
+		{
+			name: "no ready endpoints",
+			service: k8s.ServiceDetails{
+				ServiceInfo:  k8s.ServiceInfo{Name: "lb", Namespace: "shop", Type: "LoadBalancer"},
+				Selector:     map[string]string{"app": "lb"},
+				Endpoints:    []k8s.EndpointInfo{{Address: "10.1.0.9"}},
+				MatchingPods: intPtr(1),
+				Events: []k8s.EventInfo{
+					{Type: "Normal", Reason: "EnsuredLoadBalancer", Message: "Ensured load balancer", Count: 1, LastSeen: testNow.Add(-3 * time.Hour)},
+				},
+			},
+			want: []string{
+				"⚠️ **No Ready Endpoints**",
+				"⚖️ **Load Balancer**",
+				"- **EnsuredLoadBalancer** (3.0h ago): Ensured load balancer\n",
+			},
+		},
+		{
+			name: "external name without selector",
+			service: k8s.ServiceDetails{
+				ServiceInfo: k8s.ServiceInfo{Name: "db", Namespace: "shop", Type: "ExternalName"},
+			},
+			want:    []string{"🔗 **External Name**"},
+			notWant: []string{"No Ready Endpoints", "No Matching Pods", "## Selector"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatServiceForAI(&tt.service), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatDeploymentForAI(t *testing.T) {
+	info := func(total, ready, updated int32) k8s.DeploymentInfo {
+		return k8s.DeploymentInfo{
+			Name:            "web",
+			Namespace:       "shop",
+			TotalReplicas:   total,
+			ReadyReplicas:   ready,
+			UpdatedReplicas: updated,
+			Strategy:        "RollingUpdate",
+			CreatedAt:       testNow.Add(-72 * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		deployment k8s.DeploymentDetails
+		want       []string
+		notWant    []string
+	}{
+		{
+			name: "healthy",
+			deployment: k8s.DeploymentDetails{
+				DeploymentInfo: info(3, 3, 3),
+				Selector:       map[string]string{"app": "web"},
+				Conditions:     []string{"Available: Deployment has minimum availability."},
+			},
+			want: []string{
+				"**Name**: web\n",
+				"**Namespace**: shop\n",
+				"**Strategy**: RollingUpdate\n",
+				"**Status**: 🟢 Healthy\n",
+				"**Replicas**: 3 total, 3 ready, 3 updated\n",
+				"**Progress**: 100%\n",
+				"**Created At**: 3.0d\n",
+				"## Selector:\n- app: web\n",
+				"## Conditions:\n- Available: Deployment has minimum availability.\n",
+				"✅ **Status**: Deployment is healthy",
+			},
+			notWant: []string{"Action Needed"},
+		},
+		{
+			name:       "scaling",
+			deployment: k8s.DeploymentDetails{DeploymentInfo: info(4, 1, 2)},
+			want: []string{
+				"**Status**: 🟠 Scaling\n",
+				"**Replicas**: 4 total, 1 ready, 2 updated\n",
+				"**Progress**: 25%\n",
+				"⚠️ **Action Needed**",
+			},
+			notWant: []string{"✅", "## Selector", "## Conditions"},
+		},
+		{
+			name: "unhealthy",
+			deployment: k8s.DeploymentDetails{
+				DeploymentInfo: info(2, 0, 2),
+				Events: []k8s.EventInfo{
+					{Type: "Warning", Reason: "FailedCreate", Message: "quota exceeded", Count: 12, LastSeen: testNow.Add(-10 * time.Minute)},
+				},
+			},
+			want: []string{
+				"**Status**: 🔴 Unhealthy\n",
+				"**Progress**: 0%\n",
+				"- ⚠️ **FailedCreate** x12 (10m ago): quota exceeded\n",
+			},
+		},
+		{
+			name:       "scaled to zero",
+			deployment: k8s.DeploymentDetails{DeploymentInfo: info(0, 0, 0)},
+			want:       []string{"**Status**: 🟢 Healthy\n", "**Replicas**: 0 total, 0 ready, 0 updated\n"},
+			notWant:    []string{"**Progress**", "Action Needed"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatDeploymentForAI(&tt.deployment), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatStatefulSetForAI(t *testing.T) {
+	info := func(total, ready, partition int32) k8s.StatefulSetInfo {
+		return k8s.StatefulSetInfo{
+			Name:            "db",
+			Namespace:       "shop",
+			TotalReplicas:   total,
+			ReadyReplicas:   ready,
+			CurrentReplicas: total,
+			UpdatedReplicas: total,
+			ServiceName:     "db-headless",
+			UpdateStrategy:  "RollingUpdate",
+			Partition:       partition,
+			CreatedAt:       testNow.Add(-48 * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		statefulset k8s.StatefulSetDetails
+		want        []string
+		notWant     []string
+	}{
+		{
+			name: "healthy",
+			statefulset: k8s.StatefulSetDetails{
+				StatefulSetInfo:     info(2, 2, 0),
+				PodManagementPolicy: "OrderedReady",
+				CurrentRevision:     "db-5d8",
+				UpdateRevision:      "db-5d8",
+				Ordinals: []k8s.StatefulSetOrdinal{
+					{Ordinal: 0, Pod: "db-0", Phase: "Running", Ready: true},
+					{Ordinal: 1, Pod: "db-1", Phase: "Running", Ready: true},
+				},
+				VolumeClaimTemplates: []k8s.VolumeClaimTemplateInfo{
+					{Name: "data", Storage: "10Gi", AccessModes: []string{"ReadWriteOnce"}, StorageClass: "fast"},
+				},
+			},
+			want: []string{
+				"**Service**: db-headless\n",
+				"**Status**: 🟢 Healthy\n",
+				"**Replicas**: 2 total, 2 ready, 2 current, 2 updated\n",
+				"**Update Strategy**: RollingUpdate\n",
+				"**Pod Management**: OrderedReady\n",
+				"**Revision**: db-5d8\n",
+				"**Created At**: 2.0d\n",
+				"## Ordinals:\n- 🟢 **db-0**: Running, Ready\n- 🟢 **db-1**: Running, Ready\n",
+				"## Volume Claim Templates:\n- **data**: 10Gi, ReadWriteOnce, storage class fast\n",
+				"✅ **Status**: StatefulSet is healthy",
+			},
+			notWant: []string{"Action Needed", "partition", "rollout in progress"},
+		},
+		{
+			name: "ordered ready blocked by a pod",
+			statefulset: k8s.StatefulSetDetails{
+				StatefulSetInfo:     info(3, 1, 0),
+				PodManagementPolicy: "OrderedReady",
+				Ordinals: []k8s.StatefulSetOrdinal{
+					{Ordinal: 0, Pod: "db-0", Phase: "Running", Ready: true},
+					{Ordinal: 1, Pod: "db-1", Phase: "Pending"},
+					{Ordinal: 2, Pod: "db-2"},
+				},
+			},
+			want: []string{
+				"**Status**: 🟠 Scaling\n",
+				"- 🔴 **db-1**: Pending, Not Ready\n",
+				"- 🔴 **db-2**: Missing\n",
+				"⚠️ **Action Needed**: Pod db-1 is not ready. With OrderedReady pod management",
+			},
+			notWant: []string{"✅", "## Volume Claim Templates"},
+		},
+		{
+			name: "pods unreadable",
+			statefulset: k8s.StatefulSetDetails{
+				StatefulSetInfo:     info(2, 2, 0),
+				PodManagementPolicy: "OrderedReady",
+			},
+			want:    []string{"## Ordinals:\n*Pods could not be read.*\n"},
+			notWant: []string{"- 🟢"},
+		},
+		{
+			name: "partitioned rollout",
+			statefulset: k8s.StatefulSetDetails{
+				StatefulSetInfo:     info(3, 3, 2),
+				PodManagementPolicy: "Parallel",
+				CurrentRevision:     "db-1",
+				UpdateRevision:      "db-2",
+			},
+			want: []string{
+				"**Update Strategy**: RollingUpdate (partition 2)\n",
+				"**Revision**: db-1 -> db-2 (rollout in progress)\n",
+				"ℹ️ **Partitioned Rollout**: Only ordinals >= 2 move to the new revision",
+			},
+			notWant: []string{"✅", "Action Needed"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatStatefulSetForAI(&tt.statefulset), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatDaemonSetForAI(t *testing.T) {
+	info := func(desired, scheduled, misscheduled, ready int32) k8s.DaemonSetInfo {
+		return k8s.DaemonSetInfo{
+			Name:                   "agent",
+			Namespace:              "shop",
+			DesiredNumberScheduled: desired,
+			CurrentNumberScheduled: scheduled,
+			NumberMisscheduled:     misscheduled,
+			NumberReady:            ready,
+			NumberAvailable:        ready,
+			UpdatedNumberScheduled: scheduled,
+			UpdateStrategy:         "RollingUpdate",
+			CreatedAt:              testNow.Add(-time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		daemonset k8s.DaemonSetDetails
+		want      []string
+		notWant   []string
+	}{
+		{
+			name: "healthy",
+			daemonset: k8s.DaemonSetDetails{
+				DaemonSetInfo: func() k8s.DaemonSetInfo {
+					d := info(3, 3, 0, 3)
+					d.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+					return d
+				}(),
+				Selector: map[string]string{"app": "agent"},
+			},
+			want: []string{
+				"**Status**: 🟢 Healthy\n",
+				"**Pods**: 3 desired, 3 scheduled, 3 ready, 3 available, 3 updated\n",
+				"**Update Strategy**: RollingUpdate\n",
+				"## Node Selector:\nPods only run on nodes with these labels:\n- kubernetes.io/os: linux\n",
+				"## Selector:\n- app: agent\n",
+				"✅ **Status**: DaemonSet is healthy",
+			},
+			notWant: []string{"Misscheduled", "Not Scheduled", "Action Needed"},
+		},
+		{
+			name:      "unscheduled, misscheduled and unready pods",
+			daemonset: k8s.DaemonSetDetails{DaemonSetInfo: info(5, 3, 1, 2)},
+			want: []string{
+				"**Status**: 🟠 Degraded\n",
+				"**⚠️ Misscheduled**: 1\n",
+				"⚠️ **Not Scheduled**: 2 eligible nodes have no daemon pod",
+				"⚠️ **Misscheduled**: 1 pods run on nodes that should not run them",
+				"⚠️ **Action Needed**: 1 scheduled pods are not ready",
+			},
+			notWant: []string{"✅", "## Node Selector"},
+		},
+		{
+			name:      "no eligible nodes",
+			daemonset: k8s.DaemonSetDetails{DaemonSetInfo: info(0, 0, 0, 0)},
+			want:      []string{"**Status**: 🟢 Healthy\n", "ℹ️ **No Eligible Nodes**"},
+			notWant:   []string{"✅", "Action Needed"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatDaemonSetForAI(&tt.daemonset), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatReplicaSetForAI(t *testing.T) {
+	info := func(total, ready int32) k8s.ReplicaSetInfo {
+		return k8s.ReplicaSetInfo{
+			Name:              "web-7d9f",
+			Namespace:         "shop",
+			TotalReplicas:     total,
+			ReadyReplicas:     ready,
+			AvailableReplicas: ready,
+			Owner:             "web",
+			Revision:          "4",
+			CreatedAt:         testNow.Add(-30 * time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		replicaset k8s.ReplicaSetDetails
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "healthy",
+			replicaset: k8s.ReplicaSetDetails{ReplicaSetInfo: info(2, 2)},
+			want: []string{
+				"**Owner**: Deployment web (revision 4)\n",
+				"**Status**: 🟢 Healthy\n",
+				"**Replicas**: 2 total, 2 ready, 2 available\n",
+				"**Created At**: 30m\n",
+				"✅ **Status**: ReplicaSet is healthy",
+			},
+		},
+		{
+			name:       "old revision",
+			replicaset: k8s.ReplicaSetDetails{ReplicaSetInfo: info(0, 0)},
+			want:       []string{"ℹ️ **Old Revision**: This replicaset is scaled to zero and kept by deployment web"},
+			notWant:    []string{"✅"},
+		},
+		{
+			name: "replica failure",
+			replicaset: k8s.ReplicaSetDetails{
+				ReplicaSetInfo: info(2, 0),
+				Conditions:     []string{"ReplicaFailure: pods \"web-7d9f-x\" is forbidden: exceeded quota"},
+			},
+			want:    []string{"**Status**: 🔴 Unhealthy\n", "⚠️ **Replica Failure**"},
+			notWant: []string{"✅", "Action Needed"},
+		},
+		{
+			name:       "standalone",
+			replicaset: k8s.ReplicaSetDetails{ReplicaSetInfo: func() k8s.ReplicaSetInfo { r := info(1, 1); r.Owner, r.Revision = "", ""; return r }()},
+			notWant:    []string{"**Owner**"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatReplicaSetForAI(&tt.replicaset), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatJobForAI(t *testing.T) {
+	started := testNow.Add(-10 * time.Minute)
+	info := func(status string, succeeded, failed int32) k8s.JobInfo {
+		return k8s.JobInfo{
+			Name:         "migrate",
+			Namespace:    "shop",
+			Status:       status,
+			Completions:  1,
+			Parallelism:  1,
+			Succeeded:    succeeded,
+			Failed:       failed,
+			BackoffLimit: 2,
+			StartTime:    &started,
+			CreatedAt:    started,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		job     k8s.JobDetails
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "complete",
+			job: k8s.JobDetails{JobInfo: func() k8s.JobInfo {
+				job := info("Complete", 1, 0)
+				finished := started.Add(90 * time.Second)
+				job.CompletionTime = &finished
+				job.Owner = "report"
+				return job
+			}()},
+			want: []string{
+				"**Owner**: CronJob report\n",
+				"**Status**: 🟢 Complete\n",
+				"**Completions**: 1/1 succeeded, 0 active, 0 failed\n",
+				"**Duration**: 2m\n",
+				"✅ **Status**: Job completed successfully.",
+			},
+			notWant: []string{"## Failure", "⚠️"},
+		},
+		{
+			name: "backoff limit exceeded",
+			job: k8s.JobDetails{
+				JobInfo:        info("Failed", 0, 3),
+				FailureReason:  "BackoffLimitExceeded",
+				FailureMessage: "Job has reached the specified backoff limit",
+				PodFailures:    []k8s.JobPodFailure{{Pod: "migrate-x7k2p", Container: "app", Reason: "OOMKilled", ExitCode: 137}},
+			},
+			want: []string{
+				"**Status**: 🔴 Failed\n",
+				"**Backoff Limit**: 2 failed pods allowed, 3 used\n",
+				"**Reason**: BackoffLimitExceeded\n",
+				"- **migrate-x7k2p/app**: OOMKilled (exit code 137)\n",
+				"⚠️ **Backoff Limit Reached**: 3 pods failed",
+				"⚠️ **Out of Memory**",
+			},
+			notWant: []string{"✅"},
+		},
+		{
+			name: "deadline exceeded",
+			job: k8s.JobDetails{
+				JobInfo: func() k8s.JobInfo {
+					job := info("Failed", 0, 1)
+					deadline := int64(300)
+					job.ActiveDeadlineSeconds = &deadline
+					return job
+				}(),
+				FailureReason: "DeadlineExceeded",
+				PodFailures:   []k8s.JobPodFailure{},
+			},
+			want:    []string{"**Active Deadline**: 5m\n", "⚠️ **Deadline Exceeded**"},
+			notWant: []string{"Backoff Limit Reached", "Out of Memory", "## Failed Containers"},
+		},
+		{
+			name: "retrying",
+			job: k8s.JobDetails{
+				JobInfo:     func() k8s.JobInfo { job := info("Running", 0, 1); job.Active = 1; return job }(),
+				PodFailures: []k8s.JobPodFailure{},
+			},
+			want:    []string{"**Status**: 🟢 Running\n", "**Duration**: 10m\n", "⚠️ **Retrying**: 1 of 2 allowed pod failures"},
+			notWant: []string{"✅", "## Failure", "## Failed Containers"},
+		},
+		{
+			name: "failed pods not readable",
+			job: k8s.JobDetails{
+				JobInfo:       info("Failed", 0, 3),
+				FailureReason: "BackoffLimitExceeded",
+			},
+			want:    []string{"## Failed Containers:\n*Pods could not be read.*\n", "⚠️ **Backoff Limit Reached**: 3 pods failed"},
+			notWant: []string{"Out of Memory"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatJobForAI(&tt.job), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatCronJobForAI(t *testing.T) {
+	lastRun := testNow.Add(-55 * time.Minute)
+	nextRun := testNow.Add(5 * time.Minute)
+	info := k8s.CronJobInfo{
+		Name:               "report",
+		Namespace:          "shop",
+		Schedule:           "0 * * * *",
+		ConcurrencyPolicy:  "Forbid",
+		LastScheduleTime:   &lastRun,
+		LastSuccessfulTime: &lastRun,
+		NextScheduleTime:   &nextRun,
+		CreatedAt:          testNow.Add(-48 * time.Hour),
+	}
+	job := func(name, status string, failed int32) k8s.JobInfo {
+		return k8s.JobInfo{Name: name, Namespace: "shop", Status: status, Completions: 1, Failed: failed, CreatedAt: lastRun}
+	}
+
+	tests := []struct {
+		name    string
+		cronjob k8s.CronJobDetails
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "healthy",
+			cronjob: k8s.CronJobDetails{
+				CronJobInfo:                info,
+				SuccessfulJobsHistoryLimit: 3,
+				FailedJobsHistoryLimit:     1,
+				RecentJobs:                 []k8s.JobInfo{func() k8s.JobInfo { j := job("report-2", "Complete", 0); j.Succeeded = 1; return j }()},
+			},
+			want: []string{
+				"**Schedule**: `0 * * * *`\n",
+				"**Status**: 🟢 Active\n",
+				"**Concurrency Policy**: Forbid\n",
+				"**Last Scheduled**: 55m ago\n",
+				"**Next Run**: in 5m (2025-06-01T12:05:00Z)\n",
+				"**History Limits**: 3 successful, 1 failed\n",
+				"## Recent Jobs (1):\n- 🟢 **report-2**: Complete, 1/1 succeeded, age 55m\n",
+				"✅ **Status**: CronJob runs on schedule",
+			},
+			notWant: []string{"⚠️", "⏸️"},
+		},
+		{
+			name: "repeated failures",
+			cronjob: k8s.CronJobDetails{
+				CronJobInfo:            info,
+				FailedJobsHistoryLimit: 3,
+				RecentJobs:             []k8s.JobInfo{job("report-3", "Failed", 3), job("report-2", "Failed", 3)},
+				ConsecutiveFailures:    2,
+			},
+			want:    []string{"**Status**: 🔴 Failing\n", "- 🔴 **report-3**: Failed, 0/1 succeeded, 3 failed", "⚠️ **Repeated Failures**: The last 2 jobs failed."},
+			notWant: []string{"✅", "Action Needed", "earlier runs"},
+		},
+		{
+			name: "failure with the default history limit",
+			cronjob: k8s.CronJobDetails{
+				CronJobInfo:            info,
+				FailedJobsHistoryLimit: 1,
+				RecentJobs:             []k8s.JobInfo{job("report-3", "Failed", 6)},
+				ConsecutiveFailures:    1,
+			},
+			want: []string{
+				"**Status**: 🟠 Last Run Failed\n",
+				"⚠️ **Action Needed**: The last finished job failed.",
+				"ℹ️ **History Limit**: failedJobsHistoryLimit 1 keeps no older failed jobs, so whether earlier runs failed as well cannot be told from the remaining jobs.\n",
+			},
+			notWant: []string{"✅", "Failing", "Repeated Failures"},
+		},
+		{
+			name: "repeated failures up to the history limit",
+			cronjob: k8s.CronJobDetails{
+				CronJobInfo:            info,
+				FailedJobsHistoryLimit: 2,
+				RecentJobs:             []k8s.JobInfo{job("report-3", "Failed", 3), job("report-2", "Failed", 3)},
+				ConsecutiveFailures:    2,
+			},
+			want:    []string{"**Status**: 🔴 Failing\n", "⚠️ **Repeated Failures**: The last 2 jobs failed and failedJobsHistoryLimit 2 keeps no older failed jobs, so earlier runs may have failed too."},
+			notWant: []string{"✅", "Action Needed", "**History Limit**:"},
+		},
+		{
+			name: "single failure below the history limit",
+			cronjob: k8s.CronJobDetails{
+				CronJobInfo:            info,
+				FailedJobsHistoryLimit: 3,
+				RecentJobs:             []k8s.JobInfo{job("report-3", "Failed", 6)},
+				ConsecutiveFailures:    1,
+			},
+			want:    []string{"**Status**: 🟠 Last Run Failed\n", "⚠️ **Action Needed**: The last finished job failed."},
+			notWant: []string{"✅", "Repeated Failures", "**History Limit**:"},
+		},
+		{
+			name: "suspended",
+			cronjob: k8s.CronJobDetails{CronJobInfo: func() k8s.CronJobInfo {
+				c := info
+				c.Suspended, c.NextScheduleTime, c.TimeZone = true, nil, "Europe/Berlin"
+				c.LastSuccessfulTime = nil
+				return c
+			}(), RecentJobs: []k8s.JobInfo{}},
+			want:    []string{"**Schedule**: `0 * * * *` (Europe/Berlin)\n", "**Status**: ⏸️ Suspended\n", "**Last Successful**: never\n", "**Next Run**: none while suspended\n", "⏸️ **Suspended**"},
+			notWant: []string{"✅", "## Recent Jobs"},
+		},
+		{
+			name: "invalid schedule",
+			cronjob: k8s.CronJobDetails{CronJobInfo: func() k8s.CronJobInfo {
+				c := info
+				c.Schedule, c.NextScheduleTime = "every day", nil
+				c.ScheduleError = "invalid schedule \"every day\": expected exactly 5 fields, found 2: [every day]"
+				return c
+			}()},
+			want:    []string{"**Status**: 🔴 Failing\n", "⚠️ **Invalid Schedule**: invalid schedule"},
+			notWant: []string{"✅", "**Next Run**"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatCronJobForAI(&tt.cronjob), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatConfigMapForAI(t *testing.T) {
+	large := strings.Repeat("a", maxConfigMapValueBytes-1) + "é" + "tail"
+
+	tests := []struct {
+		name      string
+		configmap k8s.ConfigMapInfo
+		want      []string
+		notWant   []string
+	}{
+		{
+			name: "data in key order with labels",
+			configmap: k8s.ConfigMapInfo{
+				Name:      "settings",
+				Namespace: "shop",
+				Data:      map[string]string{"b.yaml": "replicas: 2", "a.env": "MODE=prod"},
+				Labels:    map[string]string{"app": "web"},
+				CreatedAt: testNow.Add(-90 * time.Second),
+			},
+			want: []string{
+				"**Name**: settings\n",
+				"**Namespace**: shop\n",
+				"**Created At**: 2m\n",
+				"## Data (2 keys):\n\n### a.env\n\n```\nMODE=prod\n```\n\n### b.yaml\n\n```\nreplicas: 2\n```\n",
+				"## Labels:\n- app: web\n",
+			},
+			notWant: []string{"## Binary Data", "not shown"},
+		},
+		{
+			name: "large values are truncated on a character boundary",
+			configmap: k8s.ConfigMapInfo{
+				Name:      "big",
+				Namespace: "shop",
+				Data:      map[string]string{"blob": large},
+			},
+			want:    []string{"\n```\n" + strings.Repeat("a", maxConfigMapValueBytes-1) + "\n```\n*6 more bytes not shown*\n"},
+			notWant: []string{"tail"},
+		},
+		{
+			name: "binary data is summarized",
+			configmap: k8s.ConfigMapInfo{
+				Name:       "certs",
+				Namespace:  "shop",
+				BinaryData: map[string]int{"z.der": 1200, "ca.der": 880},
+			},
+			want: []string{"## Data (0 keys):\n", "## Binary Data (2 keys):\n- ca.der: 880 bytes\n- z.der: 1200 bytes\n"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatConfigMapForAI(&tt.configmap), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatNamespaceForAI(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace k8s.NamespaceInfo
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "active",
+			namespace: k8s.NamespaceInfo{Name: "shop", Status: "Active", Labels: map[string]string{"team": "payments"}, CreatedAt: testNow.Add(-48 * time.Hour)},
+			want:      []string{"**Name**: shop\n", "**Status**: 🟢 Active\n", "**Created At**: 2.0d\n", "## Labels:\n- team: payments\n"},
+			notWant:   []string{"Action Needed"},
+		},
+		{
+			name:      "terminating",
+			namespace: k8s.NamespaceInfo{Name: "old", Status: "Terminating", CreatedAt: testNow},
+			want:      []string{"**Status**: 🟠 Terminating\n", "**Created At**: 0s\n", "⚠️ **Action Needed**"},
+			notWant:   []string{"## Labels"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatNamespaceForAI(&tt.namespace), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatNodeForAI(t *testing.T) {
+	info := k8s.NodeInfo{
+		Name:           "node-1",
+		Status:         "Ready",
+		Roles:          []string{"control-plane"},
+		Pressures:      []string{},
+		Taints:         []string{},
+		KubeletVersion: "v1.31.2",
+		OSImage:        "Ubuntu 24.04",
+		OS:             "linux",
+		Architecture:   "arm64",
+		InternalIP:     "10.0.0.5",
+		Capacity:       k8s.NodeResources{CPU: "4", Memory: "16Gi", Pods: "110"},
+		Allocatable:    k8s.NodeResources{CPU: "3920m", Memory: "15Gi", Pods: "110"},
+		CreatedAt:      testNow.Add(-72 * time.Hour),
+	}
+	allocated := func(cpu, memory, memoryLimits int) []k8s.NodeAllocation {
+		return []k8s.NodeAllocation{
+			{Resource: "cpu", Requests: "1", Limits: "2", Allocatable: "3920m", RequestsPercent: cpu, LimitsPercent: 2 * cpu},
+			{Resource: "memory", Requests: "4Gi", Limits: "8Gi", Allocatable: "15Gi", RequestsPercent: memory, LimitsPercent: memoryLimits},
+		}
+	}
+	conditions := []k8s.NodeCondition{
+		{Type: "MemoryPressure", Status: "False", Reason: "KubeletHasSufficientMemory"},
+		{Type: "Ready", Status: "True", Reason: "KubeletReady", Message: "kubelet is posting ready status"},
+	}
+
+	tests := []struct {
+		name    string
+		node    k8s.NodeDetails
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "healthy",
+			node: k8s.NodeDetails{
+				NodeInfo:   info,
+				Conditions: conditions,
+				Pods:       []k8s.NodePod{{Name: "web-0", Namespace: "shop", Phase: "Running", CPURequests: "500m", MemoryRequests: "256Mi"}},
+				Allocated:  allocated(25, 26, 53),
+			},
+			want: []string{
+				"**Status**: 🟢 Ready\n",
+				"**Schedulable**: Yes\n",
+				"**Roles**: control-plane\n",
+				"**Kubelet Version**: v1.31.2\n",
+				"**OS**: Ubuntu 24.04 (linux/arm64)\n",
+				"**Internal IP**: 10.0.0.5\n",
+				"- **cpu**: 4 / 3920m\n",
+				"- **cpu**: 1 requested (25%), 2 limits (50%) of 3920m allocatable\n",
+				"- **pods**: 1 of 110\n",
+				"- ✅ **MemoryPressure**: False (KubeletHasSufficientMemory)\n",
+				"- ✅ **Ready**: True (KubeletReady): kubelet is posting ready status\n",
+				"## Pods (1):\n- **shop/web-0**: Running, requests cpu 500m, memory 256Mi\n",
+				"✅ **Status**: Node is ready, schedulable and under no pressure.",
+			},
+			notWant: []string{"## Taints", "⚠️", "⛔", "namespace scope"},
+		},
+		{
+			name: "cordoned under pressure",
+			node: k8s.NodeDetails{
+				NodeInfo: func() k8s.NodeInfo {
+					node := info
+					node.Unschedulable = true
+					node.Pressures = []string{"MemoryPressure", "DiskPressure"}
+					node.Taints = []string{"node.kubernetes.io/unschedulable:NoSchedule"}
+					return node
+				}(),
+				Conditions:      []k8s.NodeCondition{{Type: "MemoryPressure", Status: "True", Reason: "KubeletHasInsufficientMemory"}},
+				Pods:            []k8s.NodePod{},
+				Allocated:       allocated(40, 95, 160),
+				PodsInScopeOnly: true,
+			},
+			want: []string{
+				"**Status**: 🟠 Ready\n",
+				"**Schedulable**: ⛔ No (cordoned)\n",
+				"*Only pods in the server's namespace scope are counted.*\n",
+				"- ⚠️ **MemoryPressure**: True (KubeletHasInsufficientMemory)\n",
+				"## Taints:\n- node.kubernetes.io/unschedulable:NoSchedule\n",
+				"⚠️ **Memory Pressure**",
+				"⚠️ **Disk Pressure**",
+				"⛔ **Cordoned**",
+				"⚠️ **Nearly Full**: Pods request 95% of the allocatable memory",
+				"ℹ️ **Overcommitted**: Memory limits add up to 160%",
+			},
+			notWant: []string{"✅ **Status**", "## Pods", "PID Pressure"},
+		},
+		{
+			name: "not ready",
+			node: k8s.NodeDetails{
+				NodeInfo:   func() k8s.NodeInfo { node := info; node.Status = "Unknown"; return node }(),
+				Conditions: []k8s.NodeCondition{{Type: "Ready", Status: "Unknown", Reason: "NodeStatusUnknown", Message: "Kubelet stopped posting node status."}},
+				Allocated:  allocated(25, 26, 53),
+			},
+			want:    []string{"**Status**: 🔴 Unknown\n", "- ⚠️ **Ready**: Unknown (NodeStatusUnknown)", "⚠️ **Node Unreachable**"},
+			notWant: []string{"✅ **Status**", "Node Not Ready"},
+		},
+		{
+			name: "pods unreadable",
+			node: k8s.NodeDetails{
+				NodeInfo:        info,
+				PodsInScopeOnly: true,
+			},
+			want:    []string{"## Allocated Resources:\n*Pods on this node could not be read.*\n"},
+			notWant: []string{"namespace scope", "requested", "## Pods"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatNodeForAI(&tt.node), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatSecretForAI(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  k8s.SecretInfo
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "redacted",
+			secret: k8s.SecretInfo{
+				Name:      "db",
+				Namespace: "shop",
+				Type:      "Opaque",
+				Keys: []k8s.SecretKeyInfo{
+					{Key: "password", Size: 12, SHA256: "abc123"},
+				},
+				CreatedAt: testNow.Add(-time.Minute),
+			},
+			want:    []string{"# Secret: shop/db\n", "**Type:** Opaque\n", "**Age:** 1m\n", "## Keys (1)\n", "- **password**: 12 bytes, sha256 `abc123`\n"},
+			notWant: []string{"```\n"},
+		},
+		{
+			name: "revealed key",
+			secret: k8s.SecretInfo{
+				Name:      "db",
+				Namespace: "shop",
+				Type:      "Opaque",
+				Keys: []k8s.SecretKeyInfo{
+					{Key: "password", Size: 6, SHA256: "def456", Revealed: true, Value: "hunter"},
+					{Key: "user", Size: 4, SHA256: "789abc"},
+				},
+			},
+			want: []string{"- **password**: 6 bytes, sha256 `def456`\n  ```\n  hunter\n  ```\n", "- **user**: 4 bytes, sha256 `789abc`\n"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatSecretForAI(&tt.secret), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatListsForAI(t *testing.T) {
+	f := newTestFormatter()
+
+	tests := []struct {
+		name string
+		got  string
+		want []string
+	}{
+		{
+			name: "pods",
+			got: f.FormatPodListForAI([]k8s.PodInfo{
+				{Name: "web-0", Namespace: "shop", Phase: "Running", Node: "node-1", CreatedAt: testNow.Add(-time.Hour)},
+				{Name: "web-1", Namespace: "shop", Phase: "Pending", Node: "node-2", Restarts: 3, CreatedAt: testNow.Add(-10 * time.Second)},
+			}),
+			want: []string{
+				"# Pods (2):\n",
+				"- 🟢 **shop/web-0**: Running on node-1, age 1.0h\n",
+				"- 🔴 **shop/web-1**: Pending on node-2, age 10s, ⚠️ 3 restarts\n",
+			},
+		},
+		{
+			name: "services",
+			got: f.FormatServiceListForAI([]k8s.ServiceInfo{
+				{Name: "web", Namespace: "shop", Type: "ClusterIP", ClusterIP: "10.0.0.12", Ports: []k8s.ServicePort{{Port: 80, TargetPort: "http", Protocol: "TCP"}}},
+			}),
+			want: []string{"# Services (1):\n", "- **shop/web**: ClusterIP 10.0.0.12 [80->http/TCP]\n"},
+		},
+		{
+			name: "deployments",
+			got: f.FormatDeploymentListForAI([]k8s.DeploymentInfo{
+				{Name: "a", Namespace: "shop", TotalReplicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2, Strategy: "RollingUpdate"},
+				{Name: "b", Namespace: "shop", TotalReplicas: 3, ReadyReplicas: 1, UpdatedReplicas: 3, Strategy: "Recreate"},
+				{Name: "c", Namespace: "shop", TotalReplicas: 1, Strategy: "RollingUpdate"},
+			}),
+			want: []string{
+				"- 🟢 **shop/a**: 2/2 ready, 2 updated (RollingUpdate)\n",
+				"- 🟠 **shop/b**: 1/3 ready, 3 updated (Recreate)\n",
+				"- 🔴 **shop/c**: 0/1 ready, 0 updated (RollingUpdate)\n",
+			},
+		},
+		{
+			name: "statefulsets",
+			got: f.FormatStatefulSetListForAI([]k8s.StatefulSetInfo{
+				{Name: "db", Namespace: "shop", TotalReplicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1, ServiceName: "db-headless", UpdateStrategy: "RollingUpdate", Partition: 2},
+			}),
+			want: []string{"# StatefulSets (1):\n", "- 🟢 **shop/db**: 3/3 ready, 1 updated, service db-headless (RollingUpdate, partition 2)\n"},
+		},
+		{
+			name: "daemonsets",
+			got: f.FormatDaemonSetListForAI([]k8s.DaemonSetInfo{
+				{Name: "agent", Namespace: "shop", DesiredNumberScheduled: 3, CurrentNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3, NumberMisscheduled: 1},
+			}),
+			want: []string{"# DaemonSets (1):\n", "- 🟠 **shop/agent**: 3 desired, 3 scheduled, 3 ready, 3 available, ⚠️ 1 misscheduled\n"},
+		},
+		{
+			name: "replicasets",
+			got: f.FormatReplicaSetListForAI([]k8s.ReplicaSetInfo{
+				{Name: "web-7d9f", Namespace: "shop", TotalReplicas: 2, ReadyReplicas: 1, Owner: "web", Revision: "4"},
+				{Name: "cache", Namespace: "shop", TotalReplicas: 1, ReadyReplicas: 1},
+			}),
+			want: []string{"# ReplicaSets (2):\n", "- 🟠 **shop/web-7d9f**: 1/2 ready, deployment web revision 4\n", "- 🟢 **shop/cache**: 1/1 ready\n"},
+		},
+		{
+			name: "jobs",
+			got: f.FormatJobListForAI([]k8s.JobInfo{
+				{Name: "migrate", Namespace: "shop", Status: "Failed", Completions: 1, Failed: 3, CreatedAt: testNow.Add(-time.Hour)},
+				{Name: "report-2", Namespace: "shop", Status: "Complete", Completions: 1, Succeeded: 1, Owner: "report", CreatedAt: testNow.Add(-5 * time.Minute)},
+			}),
+			want: []string{
+				"# Jobs (2):\n",
+				"- 🔴 **shop/migrate**: Failed, 0/1 succeeded, ⚠️ 3 failed, age 1.0h\n",
+				"- 🟢 **shop/report-2**: Complete, 1/1 succeeded, age 5m, cronjob report\n",
+			},
+		},
+		{
+			name: "cronjobs",
+			got: f.FormatCronJobListForAI([]k8s.CronJobInfo{
+				{Name: "report", Namespace: "shop", Schedule: "0 * * * *", LastScheduleTime: func() *time.Time { t := testNow.Add(-time.Hour); return &t }(), NextScheduleTime: func() *time.Time { t := testNow.Add(time.Hour); return &t }(), Active: 1},
+				{Name: "cleanup", Namespace: "shop", Schedule: "30 2 * * *", Suspended: true},
+			}),
+			want: []string{
+				"# CronJobs (2):\n",
+				"- 🟢 **shop/report**: `0 * * * *`, last run 1.0h ago, next in 1.0h, 1 active\n",
+				"- ⏸️ **shop/cleanup**: `30 2 * * *`, last run never, suspended\n",
+			},
+		},
+		{
+			name: "configmaps",
+			got:  f.FormatConfigMapListForAI([]k8s.ConfigMapInfo{{Name: "settings", Namespace: "shop", Data: map[string]string{"b": "", "a": ""}}}),
+			want: []string{"# ConfigMaps (1):\n", "- **shop/settings**: 2 keys [a, b]\n"},
+		},
+		{
+			name: "secrets",
+			got: f.FormatSecretListForAI([]k8s.SecretInfo{
+				{Name: "tls", Namespace: "shop", Type: "kubernetes.io/tls", Keys: []k8s.SecretKeyInfo{{Key: "tls.crt"}, {Key: "tls.key"}}},
+			}),
+			want: []string{"# Secrets (1):\n", "- **shop/tls** (kubernetes.io/tls): 2 keys [tls.crt, tls.key]\n"},
+		},
+		{
+			name: "namespaces",
+			got:  f.FormatNamespaceListForAI([]k8s.NamespaceInfo{{Name: "shop", Status: "Active", CreatedAt: testNow.Add(-30 * time.Hour)}}),
+			want: []string{"# Namespaces (1):\n", "- **shop**: Active, age 1.2d\n"},
+		},
+		{
+			name: "nodes",
+			got: f.FormatNodeListForAI([]k8s.NodeInfo{
+				{Name: "node-1", Status: "Ready", Roles: []string{"control-plane"}, KubeletVersion: "v1.31.2", OS: "linux", Architecture: "amd64", CreatedAt: testNow.Add(-72 * time.Hour)},
+				{Name: "node-2", Status: "Ready", KubeletVersion: "v1.31.2", OS: "linux", Architecture: "arm64", Unschedulable: true, Pressures: []string{"DiskPressure"}, Taints: []string{"a:NoSchedule", "b:NoExecute"}, CreatedAt: testNow.Add(-time.Hour)},
+				{Name: "node-3", Status: "NotReady", KubeletVersion: "v1.30.4", OS: "linux", Architecture: "amd64", CreatedAt: testNow.Add(-time.Hour)},
+			}),
+			want: []string{
+				"# Nodes (3):\n",
+				"- 🟢 **node-1**: Ready, control-plane, v1.31.2, linux/amd64, age 3.0d\n",
+				"- 🟠 **node-2**: Ready, v1.31.2, linux/arm64, age 1.0h, ⛔ cordoned, ⚠️ DiskPressure, 2 taints\n",
+				"- 🔴 **node-3**: NotReady, v1.30.4, linux/amd64, age 1.0h\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, tt.got, tt.want, nil)
+		})
+	}
+}
+
+func TestFormatPodLogsForAI(t *testing.T) {
+	tests := []struct {
+		name    string
+		logs    k8s.PodLogs
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "lines",
+			logs:    k8s.PodLogs{Pod: "web-0", Namespace: "shop", Container: "app", Lines: 2, Logs: "started\nlistening on :8080"},
+			want:    []string{"# Logs: shop/web-0 (container app)\n", "**Lines**: 2\n", "```\nstarted\nlistening on :8080\n```\n"},
+			notWant: []string{"previous container", "Truncated", "Lines Cut", "No log lines"},
+		},
+		{
+			name:    "previous and truncated",
+			logs:    k8s.PodLogs{Pod: "web-0", Namespace: "shop", Container: "app", Previous: true, Truncated: true, Lines: 1, Logs: "panic"},
+			want:    []string{"*Showing logs of the previous container instance.*\n", "⚠️ **Truncated**: Older lines were dropped"},
+			notWant: []string{"Lines Cut"},
+		},
+		{
+			name:    "overlong line cut short",
+			logs:    k8s.PodLogs{Pod: "web-0", Namespace: "shop", Container: "app", LinesCut: true, Lines: 1, Logs: "panic"},
+			want:    []string{"⚠️ **Lines Cut**: At least one line was longer than the size limit"},
+			notWant: []string{"Truncated", "Older lines"},
+		},
+		{
+			name:    "no lines",
+			logs:    k8s.PodLogs{Pod: "web-0", Namespace: "shop", Container: "app"},
+			want:    []string{"**Lines**: 0\n", "*No log lines matched.*\n"},
+			notWant: []string{"```"},
+		},
+	}
+
+	f := newTestFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRendered(t, f.FormatPodLogsForAI(&tt.logs), tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestFormatContextListForAI(t *testing.T) {
+	f := newTestFormatter()
+	got := f.FormatContextListForAI(
+		[]k8s.ContextInfo{
+			{Name: "prod", Cluster: "prod-cluster", User: "admin", Namespace: "shop", Current: true},
+			{Name: "dev", Cluster: "dev-cluster", User: "dev"},
+		},
+		[]k8s.ClusterInfo{{Name: "prod", Context: "prod", Default: true}, {Name: "in-cluster", Context: "in-cluster"}},
+	)
+
+	assertRendered(t, got, []string{
+		"# Clusters (2):\n\n",
+		"- 👉 **prod**: context prod (cluster prod-cluster, user admin, namespace shop) (default)\n",
+		"- **in-cluster**: context in-cluster\n",
+	}, []string{"dev-cluster"})
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{59 * time.Second, "59s"},
+		{5 * time.Minute, "5m"},
+		{90 * time.Minute, "1.5h"},
+		{36 * time.Hour, "1.5d"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.d); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		maxBytes    int
+		want        string
+		wantDropped int
+	}{
+		{name: "short", s: "abc", maxBytes: 5, want: "abc"},
+		{name: "exact", s: "abcde", maxBytes: 5, want: "abcde"},
+		{name: "ascii", s: "abcdef", maxBytes: 4, want: "abcd", wantDropped: 2},
+		{name: "multi-byte character is not split", s: "abé", maxBytes: 3, want: "ab", wantDropped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := truncateValue(tt.s, tt.maxBytes)
+			if got != tt.want || dropped != tt.wantDropped {
+				t.Errorf("truncateValue(%q, %d) = %q, %d, want %q, %d", tt.s, tt.maxBytes, got, dropped, tt.want, tt.wantDropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/pkg/auth"
+	"onlylight/k8s-mcp-server/pkg/k8s"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	ssePath        = "/sse"
+	messagePath    = "/message"
+	streamablePath = "/mcp"
+	healthzPath    = "/healthz"
+
+	// shutdownTimeout bounds how long in-flight requests may take to finish on shutdown
+	shutdownTimeout = 10 * time.Second
+	// healthCheckTimeout bounds a single /healthz probe
+	healthCheckTimeout = 5 * time.Second
+	// maxMessageBytes bounds the body of a single posted MCP message
+	maxMessageBytes = 4 << 20
+)
+
+// subscriptionReply delivers the response to an intercepted subscription request
+type subscriptionReply func(w http.ResponseWriter, sessionID string, response mcp.JSONRPCMessage) error
+
+// serveHTTP runs the SSE or streamable HTTP transport until ctx is cancelled, then shuts
+// the listener down gracefully
+func (s *Server) serveHTTP(ctx context.Context, transport string) error {
+	cfg := s.config.Server
+	handler, err := s.httpHandler(transport)
+	if err != nil {
+		return err
+	}
+
+	// Event streams only end when their request context does, so they are closed as
+	// soon as shutdown starts; Shutdown then only waits for in-flight requests.
+	streamsCtx, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
+
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           endStreamsOnShutdown(streamsCtx, handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	httpServer.RegisterOnShutdown(closeStreams)
+
+	tlsEnabled := cfg.TLSCertFile != "" || cfg.TLSKeyFile != ""
+	if tlsEnabled && (cfg.TLSCertFile == "" || cfg.TLSKeyFile == "") {
+		return fmt.Errorf("both tlsCertFile and tlsKeyFile must be set to serve HTTPS")
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if tlsEnabled {
+			serveErr <- httpServer.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			serveErr <- httpServer.ListenAndServe()
+		}
+	}()
+	s.logger.Infof("Serving MCP over %s on %s (TLS: %t)", transport, cfg.ListenAddr, tlsEnabled)
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("failed to serve %s transport: %w", transport, err)
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("Shutting down HTTP listener")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		httpServer.Close()
+		return fmt.Errorf("failed to shut down %s transport: %w", transport, err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// httpHandler routes the endpoints of the SSE or streamable HTTP transport and /healthz
+func (s *Server) httpHandler(transport string) (http.Handler, error) {
+	cfg := s.config.Server
+	authenticator, err := auth.New(s.config.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up authentication: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, s.handleHealthz)
+	// Every MCP endpoint requires authentication; /healthz stays open for probes
+	handle := func(pattern string, handler http.Handler) {
+		mux.Handle(pattern, s.authenticate(authenticator, handler))
+	}
+
+	switch transport {
+	case config.TransportSSE:
+		sseServer := server.NewSSEServer(s.mcpServer,
+			server.WithBaseURL(cfg.BaseURL),
+			server.WithSSEEndpoint(ssePath),
+			server.WithMessageEndpoint(messagePath),
+			server.WithKeepAlive(true),
+		)
+		sessionID := func(r *http.Request) string { return r.URL.Query().Get("sessionId") }
+		// Responses on the SSE transport travel over the event stream, not the POST
+		reply := func(w http.ResponseWriter, sessionID string, response mcp.JSONRPCMessage) error {
+			w.WriteHeader(http.StatusAccepted)
+			return sseServer.SendEventToSession(sessionID, response)
+		}
+		handle(ssePath, sseServer.SSEHandler())
+		handle(messagePath, s.interceptSubscriptions(sseServer.MessageHandler(), sessionID, reply))
+
+	case config.TransportHTTP:
+		streamableServer := server.NewStreamableHTTPServer(s.mcpServer,
+			server.WithEndpointPath(streamablePath),
+			server.WithSessionIdManager(&sessionIDManager{owners: s.sessions}),
+		)
+		sessionID := func(r *http.Request) string { return r.Header.Get(server.HeaderKeySessionID) }
+		reply := func(w http.ResponseWriter, sessionID string, response mcp.JSONRPCMessage) error {
+			w.Header().Set("Content-Type", "application/json")
+			return json.NewEncoder(w).Encode(response)
+		}
+		handle(streamablePath, s.interceptSubscriptions(streamableServer, sessionID, reply))
+
+	default:
+		return nil, fmt.Errorf("unsupported transport %q", transport)
+	}
+
+	return mux, nil
+}
+
+// authenticate rejects requests without valid bearer credentials and attaches the caller's
+// identity to the request context. With impersonation enabled, every Kubernetes call made
+// for the request is sent as that identity. A nil authenticator lets every request through.
+func (s *Server) authenticate(authenticator auth.Authenticator, next http.Handler) http.Handler {
+	if authenticator == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := authenticator.Authenticate(r.Context(), auth.BearerToken(r))
+		if err != nil {
+			s.logger.Warnf("Rejected %s %s from %s: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="k8s-mcp-server"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := auth.WithIdentity(r.Context(), identity)
+		if s.config.Auth.Impersonate {
+			ctx = k8s.WithImpersonation(ctx, identity.User, identity.Groups)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// endStreamsOnShutdown ties long-lived GET event streams to streamsCtx
+func endStreamsOnShutdown(streamsCtx context.Context, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			stop := context.AfterFunc(streamsCtx, cancel)
+			defer stop()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// interceptSubscriptions serves resources/subscribe and resources/unsubscribe posted to an
+// HTTP transport, which mcp-go does not route, and passes everything else to next.
+// Terminating a session with DELETE also releases its subscriptions. Requests naming a
+// session that the server did not hand out to the same caller are rejected.
+func (s *Server) interceptSubscriptions(next http.Handler, sessionID func(*http.Request) string, reply subscriptionReply) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxMessageBytes)
+
+		id := sessionID(r)
+		if id == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !s.sessions.ownedBy(id, callerName(r.Context())) {
+			s.logger.Warnf("Rejected %s %s for unknown session %s from %s", r.Method, r.URL.Path, id, r.RemoteAddr)
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodDelete:
+			s.subscriptions.removeSession(id)
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+					http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if response, handled := s.handleSubscriptionMessage(r.Context(), id, json.RawMessage(body)); handled {
+				if err := reply(w, id, response); err != nil {
+					// The session is gone, so nothing could receive its updates
+					s.logger.Warnf("Failed to reply to session %s: %v", id, err)
+					s.subscriptions.removeSession(id)
+				}
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sessionOwners remembers which caller opened each MCP session, so that session IDs
+// sent to the HTTP transports can neither be made up nor borrowed from another caller
+type sessionOwners struct {
+	mu     sync.Mutex
+	owners map[string]string // session ID -> user name, empty without authentication
+}
+
+func newSessionOwners() *sessionOwners {
+	return &sessionOwners{owners: make(map[string]string)}
+}
+
+func (o *sessionOwners) bind(sessionID, user string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.owners[sessionID] = user
+}
+
+func (o *sessionOwners) remove(sessionID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.owners, sessionID)
+}
+
+// known reports whether the session was opened and is still open
+func (o *sessionOwners) known(sessionID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.owners[sessionID]
+	return ok
+}
+
+// ownedBy reports whether the session was opened by user and is still open
+func (o *sessionOwners) ownedBy(sessionID, user string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	owner, ok := o.owners[sessionID]
+	return ok && owner == user
+}
+
+// sessionIDManager is the session store of the streamable HTTP transport. Unlike mcp-go's
+// default, which accepts any well-formed ID, it only accepts IDs of initialized sessions.
+type sessionIDManager struct {
+	server.InsecureStatefulSessionIdManager
+	owners *sessionOwners
+}
+
+func (m *sessionIDManager) Validate(sessionID string) (bool, error) {
+	if !m.owners.known(sessionID) {
+		return false, fmt.Errorf("unknown session %q", sessionID)
+	}
+	return false, nil
+}
+
+func (m *sessionIDManager) Terminate(sessionID string) (bool, error) {
+	m.owners.remove(sessionID)
+	return false, nil
+}
+
+// callerName returns the authenticated user of a request, or "" without authentication
+func callerName(ctx context.Context) string {
+	if identity, ok := auth.IdentityFrom(ctx); ok {
+		return identity.User
+	}
+	return ""
+}
+
+// handleHealthz reports whether the default cluster's API server is reachable
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	status := struct {
+		Status  string `json:"status"`
+		Cluster string `json:"cluster"`
+		Error   string `json:"error,omitempty"`
+	}{
+		Status:  "ok",
+		Cluster: s.clusters.DefaultName(),
+	}
+
+	code := http.StatusOK
+	if err := s.clusters.Default().HealthCheck(ctx); err != nil {
+		code = http.StatusServiceUnavailable
+		status.Status = "unavailable"
+		status.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.logger.Warnf("Failed to write health status: %v", err)
+	}
+}
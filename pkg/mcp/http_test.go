@@ -0,0 +1,174 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/pkg/auth"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestInterceptSubscriptionsChecksSessions(t *testing.T) {
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+	s.sessions.bind("alice-session", "alice")
+
+	subscribe := `{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"k8s://pod/shop/web-0"}}`
+	tests := []struct {
+		name     string
+		session  string
+		user     string
+		body     string
+		wantCode int
+	}{
+		{name: "owner", session: "alice-session", user: "alice", body: subscribe, wantCode: http.StatusOK},
+		{name: "made-up session", session: "mcp-session-made-up", user: "alice", body: subscribe, wantCode: http.StatusNotFound},
+		{name: "another caller's session", session: "alice-session", user: "mallory", body: subscribe, wantCode: http.StatusNotFound},
+		{name: "oversized body", session: "alice-session", user: "alice", body: strings.Repeat(" ", maxMessageBytes+1), wantCode: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("request was passed on to the transport")
+			})
+			replied := false
+			reply := func(w http.ResponseWriter, sessionID string, response mcp.JSONRPCMessage) error {
+				replied = true
+				w.WriteHeader(http.StatusOK)
+				return nil
+			}
+			handler := s.interceptSubscriptions(next, func(r *http.Request) string { return r.Header.Get("Mcp-Session-Id") }, reply)
+
+			request := httptest.NewRequest(http.MethodPost, streamablePath, strings.NewReader(tt.body))
+			request.Header.Set("Mcp-Session-Id", tt.session)
+			ctx, cancel := context.WithCancel(auth.WithIdentity(context.Background(), &auth.Identity{User: tt.user}))
+			defer cancel()
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request.WithContext(ctx))
+
+			if recorder.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body)
+			}
+			if replied != (tt.wantCode == http.StatusOK) {
+				t.Errorf("subscription handled = %t, want %t", replied, tt.wantCode == http.StatusOK)
+			}
+			s.subscriptions.mu.Lock()
+			watched := len(s.subscriptions.sessions[tt.session]) > 0
+			s.subscriptions.mu.Unlock()
+			if watched && tt.wantCode != http.StatusOK {
+				t.Errorf("a watch was started for session %s", tt.session)
+			}
+		})
+	}
+}
+
+func TestHTTPTransports(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	tokens := "- token: alice-token\n  user: alice\n- token: mallory-token\n  user: mallory\n"
+	if err := os.WriteFile(tokensFile, []byte(tokens), 0o600); err != nil {
+		t.Fatalf("failed to write tokens file: %v", err)
+	}
+	bearer := func(token string) map[string]string {
+		return map[string]string{"Authorization": "Bearer " + token}
+	}
+
+	tests := []struct {
+		transport string
+		connect   func(url string) (*client.Client, error)
+		// sessionRequest posts body to the session mcpClient holds
+		sessionRequest func(url string, mcpClient *client.Client, body io.Reader) (*http.Request, error)
+	}{
+		{
+			transport: config.TransportSSE,
+			connect: func(url string) (*client.Client, error) {
+				return client.NewSSEMCPClient(url+ssePath, transport.WithHeaders(bearer("alice-token")))
+			},
+			sessionRequest: func(url string, mcpClient *client.Client, body io.Reader) (*http.Request, error) {
+				// The SSE client only knows its session through the endpoint it was sent
+				endpoint := mcpClient.GetTransport().(*transport.SSE).GetEndpoint()
+				return http.NewRequest(http.MethodPost, endpoint.String(), body)
+			},
+		},
+		{
+			transport: config.TransportHTTP,
+			connect: func(url string) (*client.Client, error) {
+				return client.NewStreamableHttpClient(url+streamablePath, transport.WithHTTPHeaders(bearer("alice-token")))
+			},
+			sessionRequest: func(url string, mcpClient *client.Client, body io.Reader) (*http.Request, error) {
+				request, err := http.NewRequest(http.MethodPost, url+streamablePath, body)
+				if err == nil {
+					request.Header.Set(server.HeaderKeySessionID, mcpClient.GetSessionId())
+					request.Header.Set("Content-Type", "application/json")
+				}
+				return request, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transport, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Server.Transport = tt.transport
+			cfg.Auth = config.AuthConfig{Type: config.AuthStatic, TokensFile: tokensFile}
+			s := newTestServer(t, cfg, fixtureObjects()...)
+			handler, err := s.httpHandler(tt.transport)
+			if err != nil {
+				t.Fatalf("httpHandler() error = %v", err)
+			}
+			httpServer := httptest.NewServer(handler)
+			defer httpServer.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			mcpClient, err := tt.connect(httpServer.URL)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer mcpClient.Close()
+			if err := mcpClient.Start(ctx); err != nil {
+				t.Fatalf("failed to start client: %v", err)
+			}
+
+			initRequest := mcp.InitializeRequest{}
+			initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initRequest.Params.ClientInfo = mcp.Implementation{Name: "http-test", Version: "1.0.0"}
+			if _, err := mcpClient.Initialize(ctx, initRequest); err != nil {
+				t.Fatalf("initialize failed: %v", err)
+			}
+
+			callRequest := mcp.CallToolRequest{}
+			callRequest.Params.Name = "list_pods"
+			result, err := mcpClient.CallTool(ctx, callRequest)
+			if err != nil || result.IsError {
+				t.Fatalf("list_pods failed: %v %+v", err, result)
+			}
+
+			// Another caller can't post to the session
+			ping := strings.NewReader(`{"jsonrpc":"2.0","id":99,"method":"ping"}`)
+			request, err := tt.sessionRequest(httpServer.URL, mcpClient, ping)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			request.Header.Set("Authorization", "Bearer mallory-token")
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("failed to post as another caller: %v", err)
+			}
+			response.Body.Close()
+			if response.StatusCode != http.StatusNotFound {
+				t.Errorf("posting to alice's session as mallory returned %d, want %d", response.StatusCode, http.StatusNotFound)
+			}
+		})
+	}
+}
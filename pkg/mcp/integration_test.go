@@ -0,0 +1,284 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"onlylight/k8s-mcp-server/pkg/k8s"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// integrationObjects extends the fixture cluster with a service, a deployment, a
+// statefulset and the node the fixture pod runs on, so every kind the resource list
+// advertises is present
+func integrationObjects() []runtime.Object {
+	replicas := int32(2)
+	setReplicas := int32(1)
+	return append(fixtureObjects(),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: fixtureCreated},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.0.0.12",
+				Selector:  map[string]string{"app": "web"},
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: fixtureCreated},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1, UpdatedReplicas: 2},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: fixtureCreated},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:    &setReplicas,
+				ServiceName: "web",
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			Status: appsv1.StatefulSetStatus{CurrentReplicas: 1, UpdatedReplicas: 1, CurrentRevision: "web-1", UpdateRevision: "web-1"},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: fixtureCreated},
+			Status: corev1.NodeStatus{
+				Capacity:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("16Gi"), corev1.ResourcePods: resource.MustParse("110")},
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3920m"), corev1.ResourceMemory: resource.MustParse("15Gi"), corev1.ResourcePods: resource.MustParse("110")},
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"}},
+				NodeInfo:    corev1.NodeSystemInfo{KubeletVersion: "v1.31.2", OperatingSystem: "linux", Architecture: "amd64", OSImage: "Ubuntu 24.04"},
+			},
+		},
+	)
+}
+
+// startIntegrationServer serves s over the stdio transport on in-memory pipes and returns
+// an initialized mcp-go client connected to it
+func startIntegrationServer(t *testing.T, s *Server) (*client.Client, *mcp.InitializeResult) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.ctx = ctx
+	if err := s.startRegistry(s.clusters.Default()); err != nil {
+		cancel()
+		t.Fatalf("failed to start resource registry: %v", err)
+	}
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.serveStdio(ctx, serverReader, serverWriter)
+	}()
+
+	// client.Start assumes a stdio transport was started when its command was spawned,
+	// so an in-memory one has to be started here
+	stdio := transport.NewIO(clientReader, clientWriter, io.NopCloser(strings.NewReader("")))
+	if err := stdio.Start(ctx); err != nil {
+		cancel()
+		t.Fatalf("failed to start transport: %v", err)
+	}
+	mcpClient := client.NewClient(stdio)
+	t.Cleanup(func() {
+		mcpClient.Close()
+		cancel()
+		serverReader.Close()
+		if err := <-served; err != nil {
+			t.Errorf("serveStdio() error = %v", err)
+		}
+	})
+
+	if err := mcpClient.Start(ctx); err != nil {
+		t.Fatalf("failed to start client: %v", err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "integration-test", Version: "1.0.0"}
+	result, err := mcpClient.Initialize(ctx, initRequest)
+	if err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return mcpClient, result
+}
+
+// assertGolden compares got, rendered as indented JSON, with testdata/golden/<name>.json.
+// Run the tests with -update to rewrite the file instead.
+func assertGolden(t *testing.T, name string, got any) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", name, err)
+	}
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if string(want) != string(data) {
+		t.Errorf("%s does not match %s (run with -update to accept the change)\n--- got:\n%s", name, path, data)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	cfg := testConfig()
+	cfg.Safety.RevealSecrets = []string{"shop/db/password"}
+	s := newTestServer(t, cfg, integrationObjects()...)
+	mcpClient, initResult := startIntegrationServer(t, s)
+	ctx := context.Background()
+
+	assertGolden(t, "initialize", initResult)
+
+	t.Run("resources/list", func(t *testing.T) {
+		result, err := mcpClient.ListResources(ctx, mcp.ListResourcesRequest{})
+		if err != nil {
+			t.Fatalf("resources/list failed: %v", err)
+		}
+		assertGolden(t, "resources_list", result)
+	})
+
+	t.Run("resources/templates/list", func(t *testing.T) {
+		result, err := mcpClient.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+		if err != nil {
+			t.Fatalf("resources/templates/list failed: %v", err)
+		}
+		assertGolden(t, "resources_templates_list", result)
+	})
+
+	t.Run("tools/list", func(t *testing.T) {
+		result, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			t.Fatalf("tools/list failed: %v", err)
+		}
+		assertGolden(t, "tools_list", result)
+	})
+
+	for _, tt := range []struct {
+		golden string
+		uri    string
+	}{
+		{"resources_read_pod", "k8s://pod/shop/web-0"},
+		{"resources_read_service", "k8s://service/shop/web"},
+		{"resources_read_deployment", "k8s://deployment/shop/web"},
+		{"resources_read_statefulset", "k8s://statefulset/shop/web"},
+		{"resources_read_configmap", "k8s://configmap/shop/settings"},
+		{"resources_read_secret", "k8s://secret/shop/db"},
+		{"resources_read_namespace", "k8s://test/namespace/shop"},
+		{"resources_read_node", "k8s://node/node-1"},
+	} {
+		t.Run("resources/read "+tt.uri, func(t *testing.T) {
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = tt.uri
+			result, err := mcpClient.ReadResource(ctx, request)
+			if err != nil {
+				t.Fatalf("resources/read failed: %v", err)
+			}
+			assertGolden(t, tt.golden, result)
+		})
+	}
+
+	for _, tt := range []struct {
+		golden    string
+		tool      string
+		arguments map[string]any
+	}{
+		{"tools_call_list_pods", "list_pods", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_services", "list_services", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_deployments", "list_deployments", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_statefulsets", "list_statefulsets", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_configmaps", "list_configmaps", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_secrets", "list_secrets", map[string]any{"namespace": "shop"}},
+		{"tools_call_list_nodes", "list_nodes", map[string]any{}},
+		{"tools_call_get_secret", "get_secret", map[string]any{"namespace": "shop", "name": "db", "reveal": []string{"password"}}},
+		{"tools_call_list_pods_unknown_cluster", "list_pods", map[string]any{"cluster": "prod"}},
+	} {
+		t.Run("tools/call "+tt.golden, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = tt.tool
+			request.Params.Arguments = tt.arguments
+			result, err := mcpClient.CallTool(ctx, request)
+			if err != nil {
+				t.Fatalf("tools/call failed: %v", err)
+			}
+			assertGolden(t, tt.golden, result)
+		})
+	}
+
+	t.Run("tools/call refused", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "get_secret"
+		request.Params.Arguments = map[string]any{"namespace": "shop", "name": "settings", "reveal": []string{"mode"}}
+		_, err := mcpClient.CallTool(ctx, request)
+		if err == nil || !strings.Contains(err.Error(), ErrRefused.Error()) {
+			t.Fatalf("tools/call error = %v, want a safety refusal", err)
+		}
+	})
+}
+
+// TestIntegrationScopedServer checks that a server limited to one namespace advertises
+// and serves nothing outside it
+func TestIntegrationScopedServer(t *testing.T) {
+	cfg := testConfig()
+	cfg.K8s.Namespaces = []string{"kube-system"}
+	s := newTestServer(t, cfg, integrationObjects()...)
+	mcpClient, _ := startIntegrationServer(t, s)
+	ctx := context.Background()
+
+	resources, err := mcpClient.ListResources(ctx, mcp.ListResourcesRequest{})
+	if err != nil {
+		t.Fatalf("resources/list failed: %v", err)
+	}
+	if len(resources.Resources) != 0 {
+		t.Errorf("resources/list advertised %d out-of-scope resources", len(resources.Resources))
+	}
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "k8s://pod/shop/web-0"
+	if _, err := mcpClient.ReadResource(ctx, request); err == nil || !strings.Contains(err.Error(), k8s.ErrOutOfScope.Error()) {
+		t.Errorf("resources/read error = %v, want an out-of-scope error", err)
+	}
+
+	// Nodes belong to no namespace, but their pods from other namespaces stay hidden
+	request.Params.URI = "k8s://node/node-1"
+	result, err := mcpClient.ReadResource(ctx, request)
+	if err != nil {
+		t.Fatalf("resources/read of a node failed: %v", err)
+	}
+	text := result.Contents[0].(mcp.TextResourceContents).Text
+	if !strings.Contains(text, "- **pods**: 0 of 110") || strings.Contains(text, "web-0") {
+		t.Errorf("node read counted pods outside the scope:\n%s", text)
+	}
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// logForwarder is a logrus hook that forwards server logs to clients as
+// notifications/message. Only entries logged with the context of a request, e.g. through
+// logger.WithContext(ctx), are forwarded, and only to the session that made the request,
+// so no client sees the URIs or identities of another. A session only receives entries at
+// or above the level it chose with logging/setLevel (error until it does); the configured
+// log level still decides which entries are produced at all.
+type logForwarder struct {
+	mcpServer *server.MCPServer
+}
+
+func newLogForwarder() *logForwarder {
+	return &logForwarder{}
+}
+
+// Levels implements logrus.Hook
+func (f *logForwarder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. Delivery failures are dropped silently, since logging
+// them would feed back into this hook.
+func (f *logForwarder) Fire(entry *logrus.Entry) error {
+	if f.mcpServer == nil || entry.Context == nil {
+		return nil
+	}
+	session := server.ClientSessionFromContext(entry.Context)
+	if session == nil {
+		return nil
+	}
+
+	data := map[string]interface{}{"message": entry.Message}
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		data[key] = value
+	}
+	notification := mcp.NewLoggingMessageNotification(mcpLogLevel(entry.Level), "k8s-mcp-server", data)
+	_ = f.mcpServer.SendLogMessageToSpecificClient(session.SessionID(), notification)
+	return nil
+}
+
+// mcpLogLevel maps logrus levels onto the syslog-style levels MCP uses
+func mcpLogLevel(level logrus.Level) mcp.LoggingLevel {
+	switch level {
+	case logrus.PanicLevel:
+		return mcp.LoggingLevelEmergency
+	case logrus.FatalLevel:
+		return mcp.LoggingLevelCritical
+	case logrus.ErrorLevel:
+		return mcp.LoggingLevelError
+	case logrus.WarnLevel:
+		return mcp.LoggingLevelWarning
+	case logrus.InfoLevel:
+		return mcp.LoggingLevelInfo
+	default:
+		return mcp.LoggingLevelDebug
+	}
+}
@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrRefused is returned when the safety policy refuses a tool call or resource access
+var ErrRefused = errors.New("refused by safety policy")
+
+// toolPolicy describes what a registered tool does, so the safety policy can judge calls to it
+type toolPolicy struct {
+	readOnly bool
+	kind     types.K8sResourceType
+}
+
+// safetyPolicy enforces config.SafetyConfig before any handler reaches the Kubernetes client
+type safetyPolicy struct {
+	cfg   config.SafetyConfig
+	tools map[string]toolPolicy
+}
+
+func newSafetyPolicy(cfg config.SafetyConfig) *safetyPolicy {
+	return &safetyPolicy{
+		cfg:   cfg,
+		tools: make(map[string]toolPolicy),
+	}
+}
+
+// checkTool decides whether a tool may be called at all
+func (p *safetyPolicy) checkTool(name string) error {
+	tool, ok := p.tools[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown tool %q", ErrRefused, name)
+	}
+
+	if slices.Contains(p.cfg.DeniedTools, name) {
+		return fmt.Errorf("%w: tool %q is denied", ErrRefused, name)
+	}
+	if len(p.cfg.AllowedTools) > 0 && !slices.Contains(p.cfg.AllowedTools, name) {
+		return fmt.Errorf("%w: tool %q is not in the allowed tools (%s)", ErrRefused, name, strings.Join(p.cfg.AllowedTools, ", "))
+	}
+	if p.cfg.ReadOnly && !tool.readOnly {
+		return fmt.Errorf("%w: tool %q is not read-only and the server is in read-only mode", ErrRefused, name)
+	}
+
+	return p.checkKind(tool.kind)
+}
+
+// checkKind refuses access to denied resource kinds
+func (p *safetyPolicy) checkKind(kind types.K8sResourceType) error {
+	if kind == "" {
+		return nil
+	}
+
+	for _, denied := range p.cfg.DeniedKinds {
+		if strings.EqualFold(denied, string(kind)) {
+			return fmt.Errorf("%w: access to %s resources is denied", ErrRefused, kind)
+		}
+	}
+	return nil
+}
+
+// checkReveal refuses to reveal a secret value unless a revealSecrets pattern matches it
+func (p *safetyPolicy) checkReveal(namespace, name, key string) error {
+	target := namespace + "/" + name + "/" + key
+	for _, pattern := range p.cfg.RevealSecrets {
+		if ok, _ := path.Match(pattern, target); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: revealing %s is not allowed by revealSecrets", ErrRefused, target)
+}
+
+// checkResource decides whether a resource URI may be read or subscribed to. Namespaces
+// are left to the cluster client, which enforces the kubernetes.namespaces scope; that
+// scope does not cover nodes, so only deniedKinds keeps them from being read.
+func (p *safetyPolicy) checkResource(identifier *types.ResourceIdentifier) error {
+	return p.checkKind(identifier.Type)
+}
+
+// addTool registers a tool together with the facts the safety policy needs about it
+func (s *Server) addTool(tool mcp.Tool, kind types.K8sResourceType, handler server.ToolHandlerFunc) {
+	readOnly := tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+	s.safety.tools[tool.Name] = toolPolicy{readOnly: readOnly, kind: kind}
+	s.mcpServer.AddTool(tool, handler)
+}
+
+// safetyMiddleware enforces the safety policy on every tool call before its handler runs
+func (s *Server) safetyMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := s.safety.checkTool(request.Params.Name); err != nil {
+			s.logger.WithContext(ctx).Warnf("Refused tool call %s: %v", request.Params.Name, err)
+			return nil, err
+		}
+
+		return next(ctx, request)
+	}
+}
+
+// filterTools hides tools from tools/list that the safety policy would refuse anyway
+func (s *Server) filterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	var allowed []mcp.Tool
+	for _, tool := range tools {
+		if s.safety.checkTool(tool.Name) == nil {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
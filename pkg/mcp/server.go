@@ -3,8 +3,11 @@ package mcp
 import (
 	"context"
 	"fmt"
-	"strings"
+	"os"
+	"sync"
+	"time"
 
+	"onlylight/k8s-mcp-server/internal/audit"
 	"onlylight/k8s-mcp-server/internal/config"
 	"onlylight/k8s-mcp-server/internal/logging"
 	"onlylight/k8s-mcp-server/pkg/k8s"
@@ -17,39 +20,124 @@ import (
 // Server represents the MCP server
 type Server struct {
 	config    *config.Config
-	k8sClient *k8s.Client
 	logger    *logging.Logger
 	mcpServer *server.MCPServer
 	formatter *ResourceFormatter
+
+	clusters *k8s.ClusterRegistry
+
+	// registryMu guards the resource registry of the default cluster, which is
+	// replaced when a client switches the default cluster
+	registryMu   sync.RWMutex
+	registry     *k8s.ResourceRegistry
+	stopRegistry context.CancelFunc
+	ctx          context.Context
+
+	subscriptions *subscriptionManager
+	sessions      *sessionOwners
+	safety        *safetyPolicy
+	audit         *audit.Logger // nil when auditing is disabled
 }
 
+// listChangedDebounce bounds how often resources/list_changed is sent while
+// objects are churning, e.g. during a rollout.
+const listChangedDebounce = 2 * time.Second
+
 // NewServer creates a new MCP server instance with proper MCP protocol implementation
-func NewServer(cfg *config.Config, k8sClient *k8s.Client) *Server {
-	logger := logging.NewLogger("info", "text")
+func NewServer(cfg *config.Config, clusters *k8s.ClusterRegistry, logger *logging.Logger) *Server {
+	s := &Server{
+		config:        cfg,
+		clusters:      clusters,
+		logger:        logger,
+		formatter:     NewResourceFormatter(),
+		subscriptions: newSubscriptionManager(),
+		sessions:      newSessionOwners(),
+		safety:        newSafetyPolicy(cfg.Safety),
+	}
+
+	// Release the resource watches of clients that disconnect
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		s.subscriptions.removeSession(session.SessionID())
+		// SSE sessions end with their event stream, while streamable HTTP sessions
+		// outlive their GET streams and end with DELETE instead
+		if s.config.Server.Transport == config.TransportSSE {
+			s.sessions.remove(session.SessionID())
+		}
+	})
+	// Sessions belong to the caller that opened them. An SSE session is opened by the
+	// GET of its event stream, before the client posts initialize to it; a streamable
+	// HTTP session only gets its ID from initialize.
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		if s.config.Server.Transport == config.TransportSSE {
+			s.sessions.bind(session.SessionID(), callerName(ctx))
+		}
+	})
+	hooks.AddAfterInitialize(func(ctx context.Context, id any, message *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		if s.config.Server.Transport == config.TransportSSE {
+			return
+		}
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			s.sessions.bind(session.SessionID(), callerName(ctx))
+		}
+	})
 
 	// Create MCP server
-	mcpServer := server.NewMCPServer("k8s-mcp-server", "1.0.0", server.WithResourceCapabilities(true, true))
+	s.mcpServer = server.NewMCPServer("k8s-mcp-server", "1.0.0",
+		server.WithResourceCapabilities(true, true),
+		server.WithToolCapabilities(false),
+		server.WithPaginationLimit(defaultPageSize),
+		server.WithLogging(),
+		server.WithHooks(hooks),
+		// The audit middleware runs first so that refused calls are recorded too
+		server.WithToolHandlerMiddleware(s.auditMiddleware),
+		server.WithToolHandlerMiddleware(s.safetyMiddleware),
+		server.WithToolFilter(s.filterTools),
+	)
 
-	s := &Server{
-		config:    cfg,
-		k8sClient: k8sClient,
-		logger:    logger,
-		mcpServer: mcpServer,
-		formatter: NewResourceFormatter(),
-	}
+	// Forward the logs of every request to the client that made it
+	logs := newLogForwarder()
+	logs.mcpServer = s.mcpServer
+	logger.AddHook(logs)
 
-	// Register MCP resources
+	// Register MCP resources and tools
 	s.registerResources()
+	s.registerTools()
 
 	return s
 }
 
-// Start starts the MCP server with stdio transport
+// Start serves MCP over the configured transport until ctx is cancelled
 func (s *Server) Start(ctx context.Context) error {
 	s.logger.Info("Starting Kubernetes MCP Server")
 
-	// Use the convenient ServeStdio function
-	if err := server.ServeStdio(s.mcpServer); err != nil {
+	auditLog, err := openAuditLog(s.config.Audit)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	s.audit = auditLog
+	// Subscription watches record their reconnects in the audit log, so it is closed
+	// only once they have stopped
+	defer func() {
+		s.subscriptions.stopAll()
+		if err := s.audit.Close(); err != nil {
+			s.logger.Errorf("Failed to close audit log: %v", err)
+		}
+	}()
+
+	// Keep the resource list in sync with the cluster
+	s.ctx = ctx
+	if err := s.startRegistry(s.clusters.Default()); err != nil {
+		return err
+	}
+
+	switch transport := s.config.Server.Transport; transport {
+	case "", config.TransportStdio:
+		err = s.serveStdio(ctx, os.Stdin, os.Stdout)
+	default:
+		err = s.serveHTTP(ctx, transport)
+	}
+	if err != nil {
 		s.logger.Errorf("MCP server error: %v", err)
 		return fmt.Errorf("MCP server failed: %w", err)
 	}
@@ -58,120 +146,191 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
-// registerResources sets up the MCP resources and their handlers
+// supportedResourceTypes lists the types that can be read through k8s:// URIs
+const supportedResourceTypes = "pod, service, deployment, statefulset, daemonset, replicaset, job, cronjob, configmap, secret, namespace, node"
+
+// registerResources sets up the MCP resource templates and their handlers
 func (s *Server) registerResources() {
-	ctx := context.Background()
+	// A single template covers every namespaced object in the cluster, so objects
+	// created after startup are readable without re-registering anything.
+	// k8s://{cluster}/{type}/{name} URIs of cluster-scoped objects in a named cluster
+	// have the same shape as namespaced ones and are served by that template;
+	// types.ParseURI tells them apart.
+	template := mcp.NewResourceTemplate(
+		"k8s://{type}/{namespace}/{name}",
+		"Kubernetes Resource",
+		mcp.WithTemplateDescription("Kubernetes object in the default cluster, addressed by type, namespace and name. Supported types: "+supportedResourceTypes),
+		mcp.WithTemplateMIMEType("text/markdown"),
+	)
+	clusterScopedTemplate := mcp.NewResourceTemplate(
+		"k8s://{type}/{name}",
+		"Cluster-scoped Kubernetes Resource",
+		mcp.WithTemplateDescription("Cluster-scoped Kubernetes object such as a namespace or node, addressed by type and name. Prefix the type with a cluster name to target another cluster"),
+		mcp.WithTemplateMIMEType("text/markdown"),
+	)
+	clusterTemplate := mcp.NewResourceTemplate(
+		"k8s://{cluster}/{type}/{namespace}/{name}",
+		"Kubernetes Resource in Cluster",
+		mcp.WithTemplateDescription("Kubernetes object in a named cluster, addressed by cluster, type, namespace and name. Supported types: "+supportedResourceTypes),
+		mcp.WithTemplateMIMEType("text/markdown"),
+	)
+
+	s.mcpServer.AddResourceTemplate(template, s.auditResourceRead(s.handleResourceRead))
+	s.mcpServer.AddResourceTemplate(clusterScopedTemplate, s.auditResourceRead(s.handleResourceRead))
+	s.mcpServer.AddResourceTemplate(clusterTemplate, s.auditResourceRead(s.handleResourceRead))
+}
 
-	// Register Pod resource
-	services, err := s.k8sClient.ListServices(ctx, "")
+// clientFor returns the client for a cluster, or for the default cluster when cluster is empty
+func (s *Server) clientFor(cluster string) (k8s.ClusterClient, error) {
+	return s.clusters.Get(cluster)
+}
+
+// useCluster makes another registered cluster the default. Only the clusters the
+// server was configured with can be selected, never arbitrary kubeconfig contexts.
+func (s *Server) useCluster(name string) (k8s.ClusterClient, error) {
+	client, err := s.clusters.Get(name)
 	if err != nil {
-		s.logger.Errorf("Failed to list pods: %v", err)
-	} else {
-		count := 0
-		for _, service := range services {
-			if count >= 5 { // limit to 5 pods for demo purposes
-				break
-			}
-
-			resource := mcp.Resource{
-				URI:         fmt.Sprintf("k8s://service/%s/%s", service.Namespace, service.Name),
-				Name:        fmt.Sprintf("Service: %s/%s", service.Namespace, service.Name),
-				Description: fmt.Sprintf("Kubernetes Service in namespace %s (Type: %s)", service.Namespace, service.Type),
-				MIMEType:    "application/json",
-			}
-
-			s.mcpServer.AddResource(resource, s.handleResourceRead)
-		}
+		return nil, err
+	}
+
+	if err := s.startRegistry(client); err != nil {
+		return nil, err
+	}
+	if err := s.clusters.SetDefault(name); err != nil {
+		return nil, err
 	}
+
+	s.logger.Infof("Switched default cluster to %s (context %s)", name, client.ContextName())
+	return client, nil
 }
 
-func (s *Server) handleResourceRead(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	uri := request.Params.URI
-	s.logger.Infof("Handling read_resource request for URI: %s", uri)
+// startRegistry replaces the resource registry with one watching client's cluster.
+// Existing resource subscriptions keep their original watches.
+func (s *Server) startRegistry(client k8s.ClusterClient) error {
+	if !s.listsResources() {
+		s.logger.Info("Not advertising resources: callers are impersonated and the list is built from the server's own view")
+		return nil
+	}
+
+	registryCtx, stop := context.WithCancel(s.ctx)
+	registry := client.NewResourceRegistry(listChangedDebounce)
+	if err := registry.Start(registryCtx); err != nil {
+		stop()
+		return fmt.Errorf("failed to start resource registry: %w", err)
+	}
 
-	if !strings.HasPrefix(uri, "k8s://") {
-		return nil, fmt.Errorf("invalid URI format. Expected k8s://<resource-type>/<namespace>/<name>, got: %s", uri)
+	s.registryMu.Lock()
+	if s.stopRegistry != nil {
+		s.stopRegistry()
 	}
+	s.registry = registry
+	s.stopRegistry = stop
+	s.registryMu.Unlock()
 
-	// Parse URI: k8s://<resource-type>/<namespace>/<name>
-	parts := strings.Split(strings.TrimPrefix(uri, "k8s://"), "/")
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid URI format. Expected k8s://<resource-type>/<namespace>/<name>, got %d parts", len(parts))
+	s.syncResources()
+	registry.OnChange(s.syncResources)
+	return nil
+}
+
+// listsResources reports whether resources/list advertises the objects of the default
+// cluster. The registry reads them with the server's own identity, so the list would show
+// impersonated callers names that their RBAC hides from them; they read resources through
+// the URI templates instead.
+func (s *Server) listsResources() bool {
+	transport, authType := s.config.Server.Transport, s.config.Auth.Type
+	if transport == "" || transport == config.TransportStdio || authType == "" || authType == config.AuthNone {
+		return true
 	}
+	return !s.config.Auth.Impersonate
+}
 
-	resourceType, namespace, name := parts[0], parts[1], parts[2]
+// syncResources replaces the advertised resource list with the registry's current view.
+// mcp-go sends a single resources/list_changed notification for the whole update.
+func (s *Server) syncResources() {
+	var resources []server.ServerResource
+	s.registryMu.RLock()
+	registry := s.registry
+	s.registryMu.RUnlock()
 
-	var resourceTypeEnum types.K8sResourceType
-	switch resourceType {
-	case "pod":
-		resourceTypeEnum = types.ResourceTypePod
-	case "service":
-		resourceTypeEnum = types.ResourceTypeService
-	case "deployment":
-		resourceTypeEnum = types.ResourceTypeDeployment
-	default:
-		return nil, fmt.Errorf("unsupported resource type: %s. Supported types: pod, service, deployment", resourceType)
+	for _, r := range registry.Resources() {
+		// Don't advertise resources the safety policy would refuse to read
+		if identifier, err := types.ParseURI(r.URI); err != nil || s.safety.checkResource(identifier) != nil {
+			continue
+		}
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(r.URI, r.Name,
+				mcp.WithResourceDescription(r.Description),
+				mcp.WithMIMEType(r.MimeType),
+			),
+			// Reads of listed URIs are audited like reads through the templates
+			Handler: server.ResourceHandlerFunc(s.auditResourceRead(s.handleResourceRead)),
+		})
 	}
 
-	content, err := s.k8sClient.GetResource(ctx, &types.ResourceIdentifier{
-		Type:      resourceTypeEnum,
-		Namespace: namespace,
-		Name:      name,
-	})
+	s.mcpServer.SetResources(resources...)
+	s.logger.Debugf("Resource list updated with %d resources", len(resources))
+}
 
+func (s *Server) handleResourceRead(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := request.Params.URI
+	s.logger.WithContext(ctx).Infof("Handling read_resource request for URI: %s", uri)
+
+	identifier, err := types.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.safety.checkResource(identifier); err != nil {
+		s.logger.WithContext(ctx).Warnf("Refused read of %s: %v", uri, err)
+		return nil, err
+	}
+
+	client, err := s.clientFor(identifier.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := client.GetResource(ctx, identifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource %s: %w", uri, err)
 	}
 
 	// Format the content using AI-optimized formatters
 	var formattedContent string
-	var mimeType string
-
-	switch resourceType {
-	case "pod":
-		formattedContent, err = s.formatter.FormatPodForAI(content)
-		if err != nil {
-			s.logger.Errorf("Failed to format pod data: %v", err)
-			// Fall back to raw JSON
-			formattedContent = content
-			mimeType = "application/json"
-		} else {
-			mimeType = "text/markdown"
-		}
-
-	case "service":
-		formattedContent, err = s.formatter.FormatServiceForAI(content)
-		if err != nil {
-			s.logger.Errorf("Failed to format service data: %v", err)
-			// Fall back to raw JSON
-			formattedContent = content
-			mimeType = "application/json"
-		} else {
-			mimeType = "text/markdown"
-		}
-
-	case "deployment":
-		formattedContent, err = s.formatter.FormatDeploymentForAI(content)
-		if err != nil {
-			s.logger.Errorf("Failed to format deployment data: %v", err)
-			// Fall back to raw JSON
-			formattedContent = content
-			mimeType = "application/json"
-		} else {
-			mimeType = "text/markdown"
-		}
-
+	switch object := object.(type) {
+	case *k8s.PodDetails:
+		formattedContent = s.formatter.FormatPodForAI(object)
+	case *k8s.ServiceDetails:
+		formattedContent = s.formatter.FormatServiceForAI(object)
+	case *k8s.DeploymentDetails:
+		formattedContent = s.formatter.FormatDeploymentForAI(object)
+	case *k8s.StatefulSetDetails:
+		formattedContent = s.formatter.FormatStatefulSetForAI(object)
+	case *k8s.DaemonSetDetails:
+		formattedContent = s.formatter.FormatDaemonSetForAI(object)
+	case *k8s.ReplicaSetDetails:
+		formattedContent = s.formatter.FormatReplicaSetForAI(object)
+	case *k8s.JobDetails:
+		formattedContent = s.formatter.FormatJobForAI(object)
+	case *k8s.CronJobDetails:
+		formattedContent = s.formatter.FormatCronJobForAI(object)
+	case *k8s.ConfigMapInfo:
+		formattedContent = s.formatter.FormatConfigMapForAI(object)
+	case *k8s.SecretInfo:
+		// Resource reads never reveal values; get_secret does, subject to revealSecrets
+		formattedContent = s.formatter.FormatSecretForAI(object)
+	case *k8s.NamespaceInfo:
+		formattedContent = s.formatter.FormatNamespaceForAI(object)
+	case *k8s.NodeDetails:
+		formattedContent = s.formatter.FormatNodeForAI(object)
 	default:
-		// For unsupported types, return raw JSON
-		formattedContent = content
-		mimeType = "application/json"
+		return nil, fmt.Errorf("no formatter for resource %s", uri)
 	}
 
 	// Return the formatted resource contents
 	return []mcp.ResourceContents{
 		&mcp.TextResourceContents{
 			URI:      uri,
-			MIMEType: mimeType,
+			MIMEType: "text/markdown",
 			Text:     formattedContent,
 		},
 	}, nil
@@ -0,0 +1,660 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/audit"
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/internal/logging"
+	"onlylight/k8s-mcp-server/pkg/k8s"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var fixtureCreated = metav1.NewTime(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC))
+
+// fixtureObjects is a small cluster: two namespaces, a pod, a configmap and a secret
+func fixtureObjects() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}, CreationTimestamp: fixtureCreated},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-system", CreationTimestamp: fixtureCreated},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}, CreationTimestamp: fixtureCreated},
+			Spec: corev1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []corev1.Container{{Name: "app", Image: "shop/web:1.2"}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         "app",
+					Ready:        false,
+					RestartCount: 3,
+					State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "shop", CreationTimestamp: fixtureCreated},
+			Data:       map[string]string{"mode": "prod"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", CreationTimestamp: fixtureCreated},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+	}
+}
+
+// newTestServer builds a server whose default cluster "test" is a fake clientset
+// holding objects, without starting any transport
+func newTestServer(t *testing.T, cfg *config.Config, objects ...runtime.Object) *Server {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	clusters := k8s.NewClusterRegistry()
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset(objects...), "test", cfg.K8s.Namespaces, logger)
+	if err := clusters.Add("test", client); err != nil {
+		t.Fatalf("failed to register cluster: %v", err)
+	}
+
+	s := NewServer(cfg, clusters, &logging.Logger{Logger: logger})
+	s.formatter.now = func() time.Time { return fixtureCreated.Add(2 * time.Hour) }
+	return s
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		K8s:    config.K8sConfig{Namespaces: []string{"*"}},
+		Safety: config.SafetyConfig{ReadOnly: true},
+	}
+}
+
+// rpcResponse is the decoded JSON-RPC response to a request
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call sends one JSON-RPC request through the MCP server and decodes the response
+func call(t *testing.T, s *Server, method string, params any) rpcResponse {
+	t.Helper()
+
+	request, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	message := s.mcpServer.HandleMessage(context.Background(), request)
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	var response rpcResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("failed to decode response %s: %v", data, err)
+	}
+	return response
+}
+
+// toolCallResult is the part of a tools/call result the tests look at
+type toolCallResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+func (r toolCallResult) text() string {
+	var texts []string
+	for _, content := range r.Content {
+		texts = append(texts, content.Text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+func callTool(t *testing.T, s *Server, name string, arguments map[string]any) (toolCallResult, rpcResponse) {
+	t.Helper()
+
+	response := call(t, s, "tools/call", map[string]any{"name": name, "arguments": arguments})
+	var result toolCallResult
+	if response.Error == nil {
+		if err := json.Unmarshal(response.Result, &result); err != nil {
+			t.Fatalf("failed to decode tool result %s: %v", response.Result, err)
+		}
+	}
+	return result, response
+}
+
+func TestToolsList(t *testing.T) {
+	cfg := testConfig()
+	cfg.Safety.DeniedTools = []string{"get_pod_logs"}
+	s := newTestServer(t, cfg, fixtureObjects()...)
+
+	response := call(t, s, "tools/list", map[string]any{})
+	if response.Error != nil {
+		t.Fatalf("tools/list failed: %s", response.Error.Message)
+	}
+
+	var result struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		t.Fatalf("failed to decode tools: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, tool := range result.Tools {
+		names[tool.Name] = true
+	}
+	for _, want := range []string{"list_pods", "list_secrets", "get_secret", "list_namespaces", "list_contexts"} {
+		if !names[want] {
+			t.Errorf("tools/list is missing %s", want)
+		}
+	}
+	if names["get_pod_logs"] {
+		t.Error("tools/list includes the denied tool get_pod_logs")
+	}
+}
+
+func TestUseContext(t *testing.T) {
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+	staging := k8s.NewClientFromClientset(fake.NewSimpleClientset(), "staging-admin", nil, s.logger.Logger)
+	if err := s.clusters.Add("staging", staging); err != nil {
+		t.Fatalf("failed to register cluster: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.ctx = ctx
+
+	result, response := callTool(t, s, "use_context", map[string]any{"context": "staging"})
+	if response.Error != nil || result.IsError {
+		t.Fatalf("use_context failed: %v %s", response.Error, result.text())
+	}
+	if got := s.clusters.DefaultName(); got != "staging" {
+		t.Errorf("default cluster = %q, want staging", got)
+	}
+
+	// Kubeconfig contexts that the server was not configured with can't be selected
+	result, response = callTool(t, s, "use_context", map[string]any{"context": "kind-other"})
+	if response.Error == nil && !result.IsError {
+		t.Errorf("use_context of an unregistered context succeeded: %s", result.text())
+	}
+	if got := s.clusters.DefaultName(); got != "staging" {
+		t.Errorf("default cluster = %q after a failed switch, want staging", got)
+	}
+
+	// Switching changes no cluster state, so the read-only test server offers it, but
+	// shared transports don't offer it at all
+	cfg := testConfig()
+	cfg.Server.Transport = config.TransportHTTP
+	if _, response := callTool(t, newTestServer(t, cfg), "use_context", map[string]any{"context": "test"}); response.Error == nil {
+		t.Error("use_context succeeded over the http transport")
+	}
+}
+
+func TestListTools(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		want      []string
+		wantCount int
+	}{
+		{
+			name:      "pods in a namespace",
+			tool:      "list_pods",
+			arguments: map[string]any{"namespace": "shop"},
+			want:      []string{"# Pods (1):", "- 🟢 **shop/web-0**: Running on node-1, age 2.0h, ⚠️ 3 restarts"},
+			wantCount: 1,
+		},
+		{
+			name:      "namespaces",
+			tool:      "list_namespaces",
+			arguments: map[string]any{},
+			want:      []string{"# Namespaces (2):", "- **shop**: Active", "- **kube-system**: Active"},
+			wantCount: 2,
+		},
+		{
+			name:      "secrets without values",
+			tool:      "list_secrets",
+			arguments: map[string]any{"namespace": "shop"},
+			want:      []string{"- **shop/db** (Opaque): 1 keys [password]"},
+			wantCount: 1,
+		},
+		{
+			name:      "label selector",
+			tool:      "list_pods",
+			arguments: map[string]any{"labelSelector": "app=api"},
+			want:      []string{"# Pods (0):"},
+			wantCount: 0,
+		},
+	}
+
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, response := callTool(t, s, tt.tool, tt.arguments)
+			if response.Error != nil {
+				t.Fatalf("%s failed: %s", tt.tool, response.Error.Message)
+			}
+			if result.IsError {
+				t.Fatalf("%s returned an error: %s", tt.tool, result.text())
+			}
+			assertRendered(t, result.text(), tt.want, []string{"hunter2"})
+
+			var structured struct {
+				Count int `json:"count"`
+			}
+			if err := json.Unmarshal(result.StructuredContent, &structured); err != nil {
+				t.Fatalf("failed to decode structured content: %v", err)
+			}
+			if structured.Count != tt.wantCount {
+				t.Errorf("count = %d, want %d", structured.Count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestSafetyPolicyRefusesToolCalls(t *testing.T) {
+	tests := []struct {
+		name      string
+		safety    config.SafetyConfig
+		tool      string
+		arguments map[string]any
+	}{
+		{
+			name:      "kind denied",
+			safety:    config.SafetyConfig{ReadOnly: true, DeniedKinds: []string{"secret"}},
+			tool:      "list_secrets",
+			arguments: map[string]any{"namespace": "shop"},
+		},
+		{
+			name:      "reveal not configured",
+			safety:    config.SafetyConfig{ReadOnly: true},
+			tool:      "get_secret",
+			arguments: map[string]any{"namespace": "shop", "name": "db", "reveal": []string{"password"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Safety = tt.safety
+			s := newTestServer(t, cfg, fixtureObjects()...)
+
+			result, response := callTool(t, s, tt.tool, tt.arguments)
+			if response.Error == nil {
+				t.Fatalf("%s succeeded, want a refusal: %s", tt.tool, result.text())
+			}
+			if !strings.Contains(response.Error.Message, ErrRefused.Error()) {
+				t.Errorf("error = %q, want a safety refusal", response.Error.Message)
+			}
+		})
+	}
+}
+
+// TestNamespaceScope checks that kubernetes.namespaces limits tools and resources alike
+func TestNamespaceScope(t *testing.T) {
+	cfg := testConfig()
+	cfg.K8s.Namespaces = []string{"shop"}
+	s := newTestServer(t, cfg, fixtureObjects()...)
+
+	result, response := callTool(t, s, "list_pods", map[string]any{"namespace": "kube-system"})
+	if response.Error == nil && !result.IsError {
+		t.Errorf("list_pods in kube-system succeeded: %s", result.text())
+	}
+
+	result, response = callTool(t, s, "list_namespaces", map[string]any{})
+	if response.Error != nil {
+		t.Fatalf("list_namespaces failed: %s", response.Error.Message)
+	}
+	assertRendered(t, result.text(), []string{"# Namespaces (1):", "**shop**"}, []string{"kube-system"})
+
+	response = call(t, s, "resources/read", map[string]any{"uri": "k8s://namespace/kube-system"})
+	if response.Error == nil || !strings.Contains(response.Error.Message, k8s.ErrOutOfScope.Error()) {
+		t.Errorf("resources/read of kube-system error = %v, want it out of scope", response.Error)
+	}
+}
+
+// TestNamespaceScopeAllowsNodes checks that the namespace scope does not apply to nodes,
+// which belong to no namespace
+func TestNamespaceScopeAllowsNodes(t *testing.T) {
+	cfg := testConfig()
+	cfg.K8s.Namespaces = []string{"shop"}
+	s := newTestServer(t, cfg, fixtureObjects()...)
+
+	result, response := callTool(t, s, "list_nodes", map[string]any{})
+	if response.Error != nil {
+		t.Fatalf("list_nodes failed: %s", response.Error.Message)
+	}
+	assertRendered(t, result.text(), []string{"# Nodes (0):"}, nil)
+
+	response = call(t, s, "resources/read", map[string]any{"uri": "k8s://node/node-1"})
+	if response.Error == nil || strings.Contains(response.Error.Message, ErrRefused.Error()) {
+		t.Errorf("resources/read of a node error = %v, want not found rather than a refusal", response.Error)
+	}
+}
+
+func TestGetSecretReveal(t *testing.T) {
+	cfg := testConfig()
+	cfg.Safety.RevealSecrets = []string{"shop/db/*"}
+	s := newTestServer(t, cfg, fixtureObjects()...)
+
+	redacted, response := callTool(t, s, "get_secret", map[string]any{"namespace": "shop", "name": "db"})
+	if response.Error != nil || redacted.IsError {
+		t.Fatalf("get_secret failed: %v %s", response.Error, redacted.text())
+	}
+	assertRendered(t, redacted.text(), []string{"- **password**: 7 bytes, sha256 `f52fbd32"}, []string{"hunter2"})
+
+	revealed, response := callTool(t, s, "get_secret", map[string]any{"namespace": "shop", "name": "db", "reveal": []string{"password"}})
+	if response.Error != nil || revealed.IsError {
+		t.Fatalf("get_secret with reveal failed: %v %s", response.Error, revealed.text())
+	}
+	assertRendered(t, revealed.text(), []string{"  hunter2\n"}, nil)
+}
+
+func TestResourceRead(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    []string
+		notWant []string
+	}{
+		{
+			uri: "k8s://pod/shop/web-0",
+			want: []string{
+				"# Pod Summary:",
+				"**⚠️ Restarts**: 3",
+				"- **app**: 🔴 Not Ready",
+				"  - State: Waiting: CrashLoopBackOff",
+				"## Labels:\n- app: web",
+			},
+		},
+		{
+			uri:  "k8s://configmap/shop/settings",
+			want: []string{"# ConfigMap Summary:", "### mode\n\n```\nprod\n```"},
+		},
+		{
+			uri:     "k8s://secret/shop/db",
+			want:    []string{"# Secret: shop/db", "- **password**: 7 bytes"},
+			notWant: []string{"hunter2"},
+		},
+		{
+			uri:  "k8s://namespace/shop",
+			want: []string{"# Namespace Summary:", "**Status**: 🟢 Active", "- team: payments"},
+		},
+		{
+			uri:  "k8s://test/namespace/kube-system",
+			want: []string{"**Name**: kube-system"},
+		},
+	}
+
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			response := call(t, s, "resources/read", map[string]any{"uri": tt.uri})
+			if response.Error != nil {
+				t.Fatalf("resources/read failed: %s", response.Error.Message)
+			}
+
+			var result struct {
+				Contents []struct {
+					URI      string `json:"uri"`
+					MIMEType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"contents"`
+			}
+			if err := json.Unmarshal(response.Result, &result); err != nil {
+				t.Fatalf("failed to decode contents: %v", err)
+			}
+			if len(result.Contents) != 1 {
+				t.Fatalf("got %d contents, want 1", len(result.Contents))
+			}
+			content := result.Contents[0]
+			if content.URI != tt.uri || content.MIMEType != "text/markdown" {
+				t.Errorf("content uri=%q mimeType=%q", content.URI, content.MIMEType)
+			}
+			assertRendered(t, content.Text, tt.want, tt.notWant)
+		})
+	}
+}
+
+func TestResourceReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		safety config.SafetyConfig
+		uri    string
+		want   string
+	}{
+		{name: "missing object", uri: "k8s://pod/shop/missing", want: "not found"},
+		{name: "unknown cluster", uri: "k8s://prod/pod/shop/web-0", want: `unknown cluster "prod"`},
+		{name: "kind denied", safety: config.SafetyConfig{DeniedKinds: []string{"configmap"}}, uri: "k8s://configmap/shop/settings", want: ErrRefused.Error()},
+		{name: "node kind denied", safety: config.SafetyConfig{DeniedKinds: []string{"node"}}, uri: "k8s://node/node-1", want: ErrRefused.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Safety = tt.safety
+			s := newTestServer(t, cfg, fixtureObjects()...)
+
+			response := call(t, s, "resources/read", map[string]any{"uri": tt.uri})
+			if response.Error == nil {
+				t.Fatalf("resources/read of %s succeeded", tt.uri)
+			}
+			if !strings.Contains(response.Error.Message, tt.want) {
+				t.Errorf("error = %q, want it to contain %q", response.Error.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestListsResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		transport   string
+		authType    string
+		impersonate bool
+		want        bool
+	}{
+		{name: "stdio", transport: config.TransportStdio, authType: config.AuthStatic, impersonate: true, want: true},
+		{name: "http without auth", transport: config.TransportHTTP, authType: config.AuthNone, impersonate: true, want: true},
+		{name: "http with auth as the server", transport: config.TransportHTTP, authType: config.AuthJWT, want: true},
+		{name: "http with impersonated callers", transport: config.TransportHTTP, authType: config.AuthJWT, impersonate: true},
+		{name: "sse with impersonated callers", transport: config.TransportSSE, authType: config.AuthStatic, impersonate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Server.Transport = tt.transport
+			cfg.Auth = config.AuthConfig{Type: tt.authType, Impersonate: tt.impersonate}
+			if got := newTestServer(t, cfg).listsResources(); got != tt.want {
+				t.Errorf("listsResources() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceTemplatesList(t *testing.T) {
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+
+	response := call(t, s, "resources/templates/list", map[string]any{})
+	if response.Error != nil {
+		t.Fatalf("resources/templates/list failed: %s", response.Error.Message)
+	}
+
+	var result struct {
+		ResourceTemplates []struct {
+			URITemplate string `json:"uriTemplate"`
+		} `json:"resourceTemplates"`
+	}
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		t.Fatalf("failed to decode templates: %v", err)
+	}
+
+	var templates []string
+	for _, template := range result.ResourceTemplates {
+		templates = append(templates, template.URITemplate)
+	}
+	got := fmt.Sprint(templates)
+	for _, want := range []string{"k8s://{type}/{namespace}/{name}", "k8s://{type}/{name}", "k8s://{cluster}/{type}/{namespace}/{name}"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("templates %v are missing %s", templates, want)
+		}
+	}
+}
+
+func TestStopAllWaitsForWatches(t *testing.T) {
+	m := newSubscriptionManager()
+	done := make(chan struct{})
+	m.add("session", "k8s://pod/shop/web-0", func() {
+		// The watch winds down after its context is cancelled
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(done)
+		}()
+	})
+	m.track(done)
+
+	m.stopAll()
+	select {
+	case <-done:
+	default:
+		t.Fatal("stopAll() returned before the watch stopped")
+	}
+	if len(m.sessions) != 0 {
+		t.Errorf("sessions = %v after stopAll(), want none", m.sessions)
+	}
+}
+
+func TestOpenAuditLogRequiresPath(t *testing.T) {
+	if _, err := openAuditLog(config.AuditConfig{Enabled: true}); err == nil {
+		t.Error("openAuditLog() without a path succeeded")
+	}
+	if auditLog, err := openAuditLog(config.AuditConfig{}); err != nil || auditLog != nil {
+		t.Errorf("openAuditLog() of a disabled audit log = %v, %v, want nil, nil", auditLog, err)
+	}
+}
+
+// TestListedResourceReadsAreAudited checks that reading a URI from resources/list is
+// audited like a read through the URI templates
+func TestListedResourceReadsAreAudited(t *testing.T) {
+	s := newTestServer(t, testConfig(), fixtureObjects()...)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := openAuditLog(config.AuditConfig{Enabled: true, Path: path})
+	if err != nil {
+		t.Fatalf("openAuditLog() error = %v", err)
+	}
+	s.audit = auditLog
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.ctx = ctx
+	if err := s.startRegistry(s.clusters.Default()); err != nil {
+		t.Fatalf("failed to start resource registry: %v", err)
+	}
+
+	const uri = "k8s://pod/shop/web-0"
+	response := call(t, s, "resources/list", map[string]any{})
+	if response.Error != nil {
+		t.Fatalf("resources/list failed: %s", response.Error.Message)
+	}
+	if !strings.Contains(string(response.Result), `"uri":"`+uri+`"`) {
+		t.Fatalf("resources/list = %s, want it to list %s", response.Result, uri)
+	}
+	if response = call(t, s, "resources/read", map[string]any{"uri": uri}); response.Error != nil {
+		t.Fatalf("resources/read failed: %s", response.Error.Message)
+	}
+	if err := auditLog.Close(); err != nil {
+		t.Fatalf("failed to close audit log: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	var reads []audit.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var event audit.Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("failed to decode audit record %q: %v", line, err)
+		}
+		if event.Method == string(mcp.MethodResourcesRead) {
+			reads = append(reads, event)
+		}
+	}
+	if len(reads) != 1 {
+		t.Fatalf("audit log has %d resources/read records, want 1:\n%s", len(reads), data)
+	}
+	if got := reads[0]; got.Kind != audit.KindMCP || got.URI != uri || got.Outcome != audit.OutcomeSuccess || got.CorrelationID == "" {
+		t.Errorf("audit record = %+v, want a successful read of %s", got, uri)
+	}
+}
+
+// testSession is a client session that collects the notifications sent to it
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newTestSession(id string) *testSession {
+	return &testSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10)}
+}
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) SessionID() string                                   { return s.id }
+func (s *testSession) SetLogLevel(level mcp.LoggingLevel)                  {}
+func (s *testSession) GetLogLevel() mcp.LoggingLevel                       { return mcp.LoggingLevelDebug }
+
+func TestLogsGoToTheRequestingSessionOnly(t *testing.T) {
+	s := newTestServer(t, testConfig())
+	alice, bob := newTestSession("alice"), newTestSession("bob")
+	for _, session := range []*testSession{alice, bob} {
+		if err := s.mcpServer.RegisterSession(context.Background(), session); err != nil {
+			t.Fatalf("failed to register session: %v", err)
+		}
+	}
+
+	s.logger.Warn("Shutting down")
+	s.logger.WithContext(s.mcpServer.WithContext(context.Background(), alice)).Warn("Revealed key of secret shop/db")
+
+	if got := len(alice.notifications); got != 1 {
+		t.Errorf("alice received %d log messages, want 1", got)
+	}
+	if got := len(bob.notifications); got != 0 {
+		t.Errorf("bob received %d log messages, want none", got)
+	}
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stdioSessionID is the fixed session ID mcp-go assigns to the single stdio client
+const stdioSessionID = "stdio"
+
+// serveStdio runs the stdio transport until ctx is cancelled or stdin is closed.
+// Requests mcp-go does not route (resources/subscribe and resources/unsubscribe)
+// are answered here; everything else is passed through to the mcp-go stdio server.
+func (s *Server) serveStdio(ctx context.Context, stdin io.Reader, stdout io.Writer) error {
+	out := &syncWriter{w: stdout}
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		reader := bufio.NewReader(stdin)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if response, handled := s.handleSubscriptionMessage(ctx, stdioSessionID, json.RawMessage(line)); handled {
+					if err := writeMessage(out, response); err != nil {
+						s.logger.Errorf("Failed to write response: %v", err)
+					}
+				} else if _, err := pipeWriter.Write(line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	stdioServer := server.NewStdioServer(s.mcpServer)
+	err := stdioServer.Listen(ctx, pipeReader, out)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// syncWriter serializes writes so that responses written here and by the mcp-go
+// stdio server never interleave on the same stream
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+func writeMessage(w io.Writer, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
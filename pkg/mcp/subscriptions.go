@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"onlylight/k8s-mcp-server/internal/audit"
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// subscriptionManager tracks resources/subscribe requests per client session. Each
+// subscription owns a Kubernetes watch that is cancelled on unsubscribe or disconnect.
+type subscriptionManager struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]context.CancelFunc
+	watches  sync.WaitGroup // running watches, including those being cancelled
+}
+
+func newSubscriptionManager() *subscriptionManager {
+	return &subscriptionManager{
+		sessions: make(map[string]map[string]context.CancelFunc),
+	}
+}
+
+// add records a subscription, returning false if the session is already subscribed to uri
+func (m *subscriptionManager) add(sessionID, uri string, cancel context.CancelFunc) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subs, ok := m.sessions[sessionID]
+	if !ok {
+		subs = make(map[string]context.CancelFunc)
+		m.sessions[sessionID] = subs
+	}
+	if _, exists := subs[uri]; exists {
+		return false
+	}
+	subs[uri] = cancel
+	return true
+}
+
+// remove cancels the watch behind a single subscription
+func (m *subscriptionManager) remove(sessionID, uri string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.sessions[sessionID][uri]; ok {
+		cancel()
+		delete(m.sessions[sessionID], uri)
+	}
+}
+
+// removeSession cancels every watch held by a session
+func (m *subscriptionManager) removeSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, cancel := range m.sessions[sessionID] {
+		cancel()
+	}
+	delete(m.sessions, sessionID)
+}
+
+// track counts a watch as running until done is closed
+func (m *subscriptionManager) track(done <-chan struct{}) {
+	m.watches.Add(1)
+	go func() {
+		defer m.watches.Done()
+		<-done
+	}()
+}
+
+// stopAll cancels every subscription and waits until their watches have stopped
+func (m *subscriptionManager) stopAll() {
+	m.mu.Lock()
+	for sessionID, subs := range m.sessions {
+		for _, cancel := range subs {
+			cancel()
+		}
+		delete(m.sessions, sessionID)
+	}
+	m.mu.Unlock()
+
+	m.watches.Wait()
+}
+
+// handleSubscriptionMessage serves resources/subscribe and resources/unsubscribe, which
+// mcp-go advertises but does not route. It reports false for any other message so the
+// caller can hand it to the MCP server unchanged.
+func (s *Server) handleSubscriptionMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     mcp.RequestId `json:"id"`
+		Method string        `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil {
+		return nil, false
+	}
+
+	switch request.Method {
+	case methodResourcesSubscribe:
+		// The watch keeps the audit trail, so its reconnects share the subscribe's correlation ID
+		ctx, trail := s.startAudit(ctx)
+		start := time.Now()
+		err := s.subscribe(ctx, sessionID, request.Params.URI)
+		s.recordAudit(trail, audit.Event{Method: request.Method, URI: request.Params.URI}, start, err)
+		if err != nil {
+			s.logger.WithContext(ctx).Errorf("Failed to subscribe to %s: %v", request.Params.URI, err)
+			return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, err.Error(), nil), true
+		}
+	case methodResourcesUnsubscribe:
+		_, trail := s.startAudit(ctx)
+		start := time.Now()
+		s.subscriptions.remove(sessionID, request.Params.URI)
+		s.recordAudit(trail, audit.Event{Method: request.Method, URI: request.Params.URI}, start, nil)
+		s.logger.WithContext(ctx).Infof("Session %s unsubscribed from %s", sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+
+	return mcp.NewJSONRPCResponse(request.ID, mcp.Result{}), true
+}
+
+// subscribe starts a watch on the resource behind uri and forwards every change to the
+// session as notifications/resources/updated
+func (s *Server) subscribe(ctx context.Context, sessionID, uri string) error {
+	identifier, err := types.ParseURI(uri)
+	if err != nil {
+		return err
+	}
+	if err := s.safety.checkResource(identifier); err != nil {
+		return err
+	}
+
+	client, err := s.clientFor(identifier.Cluster)
+	if err != nil {
+		return err
+	}
+
+	// The watch must outlive the request, so it keeps ctx's values but not its cancellation
+	watchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	if !s.subscriptions.add(sessionID, uri, cancel) {
+		cancel()
+		return nil
+	}
+
+	done, err := client.WatchResource(watchCtx, identifier, func(eventType watch.EventType) {
+		s.logger.WithContext(ctx).Debugf("Resource %s changed (%s), notifying session %s", uri, eventType, sessionID)
+		if err := s.mcpServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{
+			"uri": uri,
+		}); err != nil {
+			s.logger.WithContext(ctx).Warnf("Failed to send resource update for %s: %v", uri, err)
+		}
+	})
+	if err != nil {
+		s.subscriptions.remove(sessionID, uri)
+		return fmt.Errorf("failed to subscribe to %s: %w", uri, err)
+	}
+	s.subscriptions.track(done)
+
+	s.logger.WithContext(ctx).Infof("Session %s subscribed to %s", sessionID, uri)
+	return nil
+}
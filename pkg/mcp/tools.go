@@ -0,0 +1,442 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"onlylight/k8s-mcp-server/internal/config"
+	"onlylight/k8s-mcp-server/pkg/k8s"
+	"onlylight/k8s-mcp-server/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// listResult is the structured payload returned by the list_* tools
+type listResult[T any] struct {
+	Count              int    `json:"count"`
+	Items              []T    `json:"items"`
+	NextCursor         string `json:"nextCursor,omitempty"`
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+}
+
+// newListToolResult returns a page as structured JSON alongside its markdown rendering,
+// pointing the client at the next page when there is one
+func newListToolResult[T any](page *k8s.ListPage[T], query string, markdown string) *mcp.CallToolResult {
+	result := listResult[T]{
+		Count:              len(page.Items),
+		Items:              page.Items,
+		NextCursor:         encodeCursor(query, page.Continue),
+		RemainingItemCount: page.RemainingItemCount,
+	}
+
+	if result.NextCursor != "" {
+		remaining := "more"
+		if result.RemainingItemCount != nil {
+			remaining = fmt.Sprintf("%d more", *result.RemainingItemCount)
+		}
+		markdown += fmt.Sprintf("\n*There are %s results. Call again with cursor `%s` to get the next page.*\n", remaining, result.NextCursor)
+	}
+
+	return mcp.NewToolResultStructured(result, markdown)
+}
+
+// registerTools sets up the MCP tools and their handlers
+func (s *Server) registerTools() {
+	s.addTool(newListTool("list_pods", "List pods with their phase, node and restart count", true), types.ResourceTypePod, s.handleListPods)
+	s.addTool(newListTool("list_services", "List services with their type, cluster IP and ports", true), types.ResourceTypeService, s.handleListServices)
+	s.addTool(newListTool("list_deployments", "List deployments with their replica status and rollout strategy", true), types.ResourceTypeDeployment, s.handleListDeployments)
+	s.addTool(newListTool("list_statefulsets", "List statefulsets with their replica status, headless service and update strategy", true), types.ResourceTypeStatefulSet, s.handleListStatefulSets)
+	s.addTool(newListTool("list_daemonsets", "List daemonsets with their desired, scheduled, misscheduled and available pod counts", true), types.ResourceTypeDaemonSet, s.handleListDaemonSets)
+	s.addTool(newListTool("list_replicasets", "List replicasets with their replica status and the deployment revision they belong to", true), types.ResourceTypeReplicaSet, s.handleListReplicaSets)
+	s.addTool(newListTool("list_jobs", "List jobs with their status, succeeded and failed pod counts and the cronjob that spawned them", true), types.ResourceTypeJob, s.handleListJobs)
+	s.addTool(newListTool("list_cronjobs", "List cronjobs with their schedule, last and next run and whether they are suspended", true), types.ResourceTypeCronJob, s.handleListCronJobs)
+	s.addTool(newListTool("list_configmaps", "List configmaps with their data keys", true), types.ResourceTypeConfigMap, s.handleListConfigMaps)
+	s.addTool(newListTool("list_secrets", "List secrets with their type and keys. Values are never shown", true), types.ResourceTypeSecret, s.handleListSecrets)
+	s.addTool(newListTool("list_namespaces", "List namespaces with their status", false), types.ResourceTypeNamespace, s.handleListNamespaces)
+	s.addTool(newListTool("list_nodes", "List nodes with their readiness, roles, kubelet version and any pressure, cordon or taints", false), types.ResourceTypeNode, s.handleListNodes)
+
+	s.addTool(mcp.NewTool("get_pod_logs",
+		mcp.WithDescription("Get container logs from a pod. Use previous=true to see why a crash-looping container died"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		withClusterArgument(),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the pod")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the pod")),
+		mcp.WithString("container", mcp.Description("Container name. Required when the pod has several containers and no default")),
+		mcp.WithNumber("tailLines", mcp.Description(fmt.Sprintf("Number of lines from the end of the log (default %d)", defaultLogTailLines)), mcp.Min(1)),
+		mcp.WithNumber("sinceSeconds", mcp.Description("Only return logs newer than this many seconds"), mcp.Min(1)),
+		mcp.WithBoolean("previous", mcp.Description("Return logs of the previous terminated container instance")),
+		mcp.WithBoolean("timestamps", mcp.Description("Prefix every line with its timestamp")),
+		mcp.WithString("grep", mcp.Description("Regular expression; only matching lines are returned")),
+		mcp.WithNumber("maxBytes", mcp.Description(fmt.Sprintf("Maximum size of the returned logs in bytes (default and maximum %d)", k8s.DefaultLogMaxBytes)), mcp.Min(1)),
+	), types.ResourceTypePod, s.handleGetPodLogs)
+
+	s.addTool(mcp.NewTool("get_secret",
+		mcp.WithDescription("Get a secret's type and keys with the size and SHA-256 fingerprint of each value. Values stay redacted unless listed in reveal and allowed by the server's configuration"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		withClusterArgument(),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Namespace of the secret")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the secret")),
+		mcp.WithArray("reveal", mcp.WithStringItems(), mcp.Description("Keys whose plaintext values to return. Every reveal is logged")),
+	), types.ResourceTypeSecret, s.handleGetSecret)
+
+	s.addTool(mcp.NewTool("list_contexts",
+		mcp.WithDescription("List the clusters the server serves with the kubeconfig context behind each, and show which cluster is the default. These are the names the cluster argument and use_context accept"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	), "", s.handleListContexts)
+
+	// The default cluster is shared by every client of the server, so only the stdio
+	// transport, which serves a single client, lets it be changed. Switching changes no
+	// cluster state, so the tool counts as read-only and stays available in read-only mode.
+	if transport := s.config.Server.Transport; transport == "" || transport == config.TransportStdio {
+		s.addTool(mcp.NewTool("use_context",
+			mcp.WithDescription("Make one of the served clusters the default. Calls without a cluster argument target it afterwards"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithString("context", mcp.Required(), mcp.Description("Name of a served cluster, as returned by list_contexts")),
+		), "", s.handleUseContext)
+	}
+}
+
+// defaultLogTailLines bounds get_pod_logs when neither tailLines nor sinceSeconds is given
+const defaultLogTailLines = 200
+
+// withClusterArgument adds the optional cluster argument shared by all cluster-bound tools
+func withClusterArgument() mcp.ToolOption {
+	return mcp.WithString("cluster",
+		mcp.Description("Cluster to query, as listed by list_contexts. Defaults to the default cluster"),
+	)
+}
+
+// newListTool builds the input schema shared by all list_* tools
+func newListTool(name, description string, namespaced bool) mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		mcp.WithReadOnlyHintAnnotation(true),
+		withClusterArgument(),
+		mcp.WithBoolean("allClusters",
+			mcp.Description("List from every cluster and label each result with its cluster. Cursors are not supported in this mode"),
+		),
+	}
+	if namespaced {
+		opts = append(opts, mcp.WithString("namespace",
+			mcp.Description("Namespace to list from. Leave empty to list across all namespaces in the server's scope"),
+		))
+	}
+	opts = append(opts,
+		mcp.WithString("labelSelector",
+			mcp.Description("Kubernetes label selector, e.g. app=web,tier!=cache"),
+		),
+		mcp.WithString("fieldSelector",
+			mcp.Description("Kubernetes field selector, e.g. spec.nodeName=node-3. Supported fields depend on the kind"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of objects to return per page (default %d)", defaultPageSize)),
+			mcp.Min(1),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor from a previous call's nextCursor to fetch the next page"),
+		),
+	)
+
+	return mcp.NewTool(name, opts...)
+}
+
+// listOptionsFromRequest reads the selector, limit and cursor arguments shared by all
+// list_* tools. It also returns the query key that cursors for this request are bound to.
+func listOptionsFromRequest(request mcp.CallToolRequest) (k8s.ListOptions, string, error) {
+	opts := k8s.ListOptions{
+		LabelSelector: request.GetString("labelSelector", ""),
+		FieldSelector: request.GetString("fieldSelector", ""),
+		Limit:         int64(request.GetInt("limit", defaultPageSize)),
+	}
+
+	query := listQuery(request.Params.Name, request.GetString("cluster", ""), request.GetString("namespace", ""), opts.LabelSelector, opts.FieldSelector)
+
+	continueToken, err := decodeCursor(request.GetString("cursor", ""), query)
+	if err != nil {
+		return opts, "", err
+	}
+	opts.Continue = continueToken
+
+	return opts, query, nil
+}
+
+// listToolError turns a failed list call into a tool error, explaining expired cursors
+func listToolError(kind string, err error) *mcp.CallToolResult {
+	if apierrors.IsResourceExpired(err) {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("The cursor for listing %s has expired; start again without a cursor", kind), err)
+	}
+	return mcp.NewToolResultErrorFromErr(fmt.Sprintf("Failed to list %s", kind), err)
+}
+
+// listFunc lists one page of objects from a single cluster
+type listFunc[T any] func(client k8s.ClusterClient, ctx context.Context, namespace string, opts k8s.ListOptions) (*k8s.ListPage[T], error)
+
+// runListTool serves a list_* tool call against one cluster, or against every cluster
+// when allClusters is set
+func runListTool[T any](ctx context.Context, s *Server, request mcp.CallToolRequest, kind string, list listFunc[T], format func([]T) string) (*mcp.CallToolResult, error) {
+	opts, query, err := listOptionsFromRequest(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	namespace := request.GetString("namespace", "")
+
+	if request.GetBool("allClusters", false) {
+		if opts.Continue != "" {
+			return mcp.NewToolResultError("cursor cannot be combined with allClusters; list a single cluster to page through it"), nil
+		}
+		return fanOutList(ctx, s, namespace, opts, kind, list, format), nil
+	}
+
+	client, err := s.clientFor(request.GetString("cluster", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	page, err := list(client, ctx, namespace, opts)
+	if err != nil {
+		return listToolError(kind, err), nil
+	}
+
+	return newListToolResult(page, query, format(page.Items)), nil
+}
+
+// clusterListResult is one cluster's share of an allClusters list call
+type clusterListResult[T any] struct {
+	Cluster            string `json:"cluster"`
+	Count              int    `json:"count"`
+	Items              []T    `json:"items"`
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// fanOutList lists the first page from every cluster concurrently. A cluster that fails
+// is reported in its own entry instead of failing the whole call.
+func fanOutList[T any](ctx context.Context, s *Server, namespace string, opts k8s.ListOptions, kind string, list listFunc[T], format func([]T) string) *mcp.CallToolResult {
+	names := s.clusters.Names()
+	results := make([]clusterListResult[T], len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results[i] = clusterListResult[T]{Cluster: name, Items: []T{}}
+			client, err := s.clientFor(name)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			page, err := list(client, ctx, namespace, opts)
+			if err != nil {
+				results[i].Error = fmt.Sprintf("failed to list %s: %v", kind, err)
+				return
+			}
+			results[i].Count = len(page.Items)
+			results[i].Items = page.Items
+			results[i].RemainingItemCount = page.RemainingItemCount
+		}()
+	}
+	wg.Wait()
+
+	markdown := &strings.Builder{}
+	for _, result := range results {
+		markdown.WriteString(fmt.Sprintf("## Cluster: %s\n\n", result.Cluster))
+		if result.Error != "" {
+			markdown.WriteString(fmt.Sprintf("⚠️ %s\n\n", result.Error))
+			continue
+		}
+		markdown.WriteString(format(result.Items))
+		if result.RemainingItemCount != nil && *result.RemainingItemCount > 0 {
+			markdown.WriteString(fmt.Sprintf("\n*%d more results in this cluster. List it alone to page through them.*\n", *result.RemainingItemCount))
+		}
+		markdown.WriteString("\n")
+	}
+
+	structured := struct {
+		Clusters []clusterListResult[T] `json:"clusters"`
+	}{Clusters: results}
+
+	return mcp.NewToolResultStructured(structured, markdown.String())
+}
+
+func (s *Server) handleListPods(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "pods", k8s.ClusterClient.ListPods, s.formatter.FormatPodListForAI)
+}
+
+func (s *Server) handleListServices(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "services", k8s.ClusterClient.ListServices, s.formatter.FormatServiceListForAI)
+}
+
+func (s *Server) handleListDeployments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "deployments", k8s.ClusterClient.ListDeployments, s.formatter.FormatDeploymentListForAI)
+}
+
+func (s *Server) handleListStatefulSets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "statefulsets", k8s.ClusterClient.ListStatefulSets, s.formatter.FormatStatefulSetListForAI)
+}
+
+func (s *Server) handleListDaemonSets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "daemonsets", k8s.ClusterClient.ListDaemonSets, s.formatter.FormatDaemonSetListForAI)
+}
+
+func (s *Server) handleListReplicaSets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "replicasets", k8s.ClusterClient.ListReplicaSets, s.formatter.FormatReplicaSetListForAI)
+}
+
+func (s *Server) handleListJobs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "jobs", k8s.ClusterClient.ListJobs, s.formatter.FormatJobListForAI)
+}
+
+func (s *Server) handleListCronJobs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "cronjobs", k8s.ClusterClient.ListCronJobs, s.formatter.FormatCronJobListForAI)
+}
+
+func (s *Server) handleListConfigMaps(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "configmaps", k8s.ClusterClient.ListConfigMaps, s.formatter.FormatConfigMapListForAI)
+}
+
+func (s *Server) handleListSecrets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return runListTool(ctx, s, request, "secrets", k8s.ClusterClient.ListSecrets, s.formatter.FormatSecretListForAI)
+}
+
+func (s *Server) handleListNamespaces(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	listNamespaces := func(client k8s.ClusterClient, ctx context.Context, _ string, opts k8s.ListOptions) (*k8s.ListPage[k8s.NamespaceInfo], error) {
+		return client.ListNamespaces(ctx, opts)
+	}
+	return runListTool(ctx, s, request, "namespaces", listNamespaces, s.formatter.FormatNamespaceListForAI)
+}
+
+func (s *Server) handleListNodes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	listNodes := func(client k8s.ClusterClient, ctx context.Context, _ string, opts k8s.ListOptions) (*k8s.ListPage[k8s.NodeInfo], error) {
+		return client.ListNodes(ctx, opts)
+	}
+	return runListTool(ctx, s, request, "nodes", listNodes, s.formatter.FormatNodeListForAI)
+}
+
+func (s *Server) handleGetPodLogs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, err := request.RequireString("namespace")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	opts := k8s.PodLogOptions{
+		Container:  request.GetString("container", ""),
+		Previous:   request.GetBool("previous", false),
+		Timestamps: request.GetBool("timestamps", false),
+		Grep:       request.GetString("grep", ""),
+		MaxBytes:   request.GetInt("maxBytes", k8s.DefaultLogMaxBytes),
+	}
+	if sinceSeconds := int64(request.GetInt("sinceSeconds", 0)); sinceSeconds > 0 {
+		opts.SinceSeconds = &sinceSeconds
+	}
+	if tailLines := int64(request.GetInt("tailLines", 0)); tailLines > 0 {
+		opts.TailLines = &tailLines
+	} else if opts.SinceSeconds == nil {
+		tailLines = defaultLogTailLines
+		opts.TailLines = &tailLines
+	}
+
+	client, err := s.clientFor(request.GetString("cluster", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logs, err := client.GetPodLogs(ctx, namespace, name, opts)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to get pod logs", err), nil
+	}
+
+	return mcp.NewToolResultStructured(logs, s.formatter.FormatPodLogsForAI(logs)), nil
+}
+
+func (s *Server) handleGetSecret(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, err := request.RequireString("namespace")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reveal := request.GetStringSlice("reveal", nil)
+	for _, key := range reveal {
+		if err := s.safety.checkReveal(namespace, name, key); err != nil {
+			s.logger.WithContext(ctx).Warnf("Refused tool call %s: %v", request.Params.Name, err)
+			return nil, err
+		}
+	}
+
+	cluster := request.GetString("cluster", "")
+	client, err := s.clientFor(cluster)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	secret, err := client.GetSecret(ctx, namespace, name, reveal)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to get secret", err), nil
+	}
+	for _, key := range reveal {
+		s.recordReveal(ctx, client.ContextName(), namespace, name, key)
+	}
+
+	return mcp.NewToolResultStructured(secret, s.formatter.FormatSecretForAI(secret)), nil
+}
+
+// handleListContexts lists the served clusters, which are the only ones use_context can
+// select, together with the kubeconfig contexts behind them. Contexts that back no
+// served cluster are left out.
+func (s *Server) handleListContexts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	clusters := s.clusters.Clusters()
+
+	served := make(map[string]bool)
+	for _, cluster := range clusters {
+		served[cluster.Context] = true
+	}
+	// The context details are informational; an in-cluster server has no kubeconfig at all
+	kubeconfigContexts, err := k8s.ListContexts(s.config.K8s.ConfigPath)
+	if err != nil {
+		s.logger.WithContext(ctx).Warnf("Failed to list kubeconfig contexts: %v", err)
+	}
+	contexts := []k8s.ContextInfo{}
+	for _, c := range kubeconfigContexts {
+		if served[c.Name] {
+			contexts = append(contexts, c)
+		}
+	}
+
+	result := struct {
+		Clusters []k8s.ClusterInfo `json:"clusters"`
+		Contexts []k8s.ContextInfo `json:"contexts"`
+	}{
+		Clusters: clusters,
+		Contexts: contexts,
+	}
+
+	return mcp.NewToolResultStructured(result, s.formatter.FormatContextListForAI(contexts, clusters)), nil
+}
+
+func (s *Server) handleUseContext(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("context")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := s.useCluster(name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("Failed to switch to %s", name), err), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Default cluster is now %s (context %s). Calls without a cluster argument target it.", name, client.ContextName())), nil
+}
@@ -2,6 +2,9 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
 )
 
 // Resource represents a Kubernetes resource exposed through MCP
@@ -26,24 +29,113 @@ type ResourceContent struct {
 type K8sResourceType string
 
 const (
-	ResourceTypePod        K8sResourceType = "pod"
-	ResourceTypeService    K8sResourceType = "service"
-	ResourceTypeDeployment K8sResourceType = "deployment"
-	ResourceTypeConfigMap  K8sResourceType = "configmap"
-	ResourceTypeSecret     K8sResourceType = "secret"
-	ResourceTypeNamespace  K8sResourceType = "namespace"
+	ResourceTypePod         K8sResourceType = "pod"
+	ResourceTypeService     K8sResourceType = "service"
+	ResourceTypeDeployment  K8sResourceType = "deployment"
+	ResourceTypeConfigMap   K8sResourceType = "configmap"
+	ResourceTypeSecret      K8sResourceType = "secret"
+	ResourceTypeNamespace   K8sResourceType = "namespace"
+	ResourceTypeStatefulSet K8sResourceType = "statefulset"
+	ResourceTypeDaemonSet   K8sResourceType = "daemonset"
+	ResourceTypeReplicaSet  K8sResourceType = "replicaset"
+	ResourceTypeJob         K8sResourceType = "job"
+	ResourceTypeCronJob     K8sResourceType = "cronjob"
+	ResourceTypeNode        K8sResourceType = "node"
 )
 
+// IsResourceType reports whether s names a known resource type
+func IsResourceType(s string) bool {
+	switch K8sResourceType(s) {
+	case ResourceTypePod, ResourceTypeService, ResourceTypeDeployment,
+		ResourceTypeConfigMap, ResourceTypeSecret, ResourceTypeNamespace,
+		ResourceTypeStatefulSet, ResourceTypeDaemonSet, ResourceTypeReplicaSet,
+		ResourceTypeJob, ResourceTypeCronJob, ResourceTypeNode:
+		return true
+	}
+	return false
+}
+
+// ClusterScoped reports whether objects of this type live outside any namespace
+func (t K8sResourceType) ClusterScoped() bool {
+	return t == ResourceTypeNamespace || t == ResourceTypeNode
+}
+
 // ResourceIdentifier uniquely identifies a Kubernetes resource
 type ResourceIdentifier struct {
+	Cluster   string          `json:"cluster,omitempty"` // empty for the default cluster
 	Type      K8sResourceType `json:"type"`
 	Namespace string          `json:"namespace"`
 	Name      string          `json:"name"`
 }
 
+// ToURI renders the identifier in the grammar ParseURI accepts. Cluster-scoped objects
+// have no namespace segment.
 func (r ResourceIdentifier) ToURI() string {
-	if r.Namespace == "" {
-		return "k8s://" + string(r.Type) + "/" + r.Name
+	prefix := "k8s://"
+	if r.Cluster != "" {
+		prefix += url.PathEscape(r.Cluster) + "/"
+	}
+
+	if r.Type.ClusterScoped() {
+		return prefix + string(r.Type) + "/" + r.Name
+	}
+	return prefix + string(r.Type) + "/" + r.Namespace + "/" + r.Name
+}
+
+// uriGrammar describes the URIs ParseURI accepts, for error messages
+const uriGrammar = "k8s://[<cluster>/]<resource-type>/<namespace>/<name> or k8s://[<cluster>/]<resource-type>/<name> for cluster-scoped types"
+
+// ParseURI parses a Kubernetes resource URI into a ResourceIdentifier:
+//
+//	k8s://[<cluster>/]<resource-type>/<namespace>/<name>  namespaced objects
+//	k8s://[<cluster>/]<resource-type>/<name>              cluster-scoped objects
+//
+// The cluster segment is optional; without it the default cluster is meant. A URI with
+// three segments is read as <resource-type>/<namespace>/<name> when its first segment is
+// a resource type, and as <cluster>/<resource-type>/<name> otherwise.
+func ParseURI(uri string) (*ResourceIdentifier, error) {
+	if !strings.HasPrefix(uri, "k8s://") {
+		return nil, fmt.Errorf("invalid URI format. Expected %s, got: %s", uriGrammar, uri)
 	}
-	return string("k8s://" + string(r.Type) + "/" + r.Namespace + "/" + r.Name)
+
+	parts := strings.Split(strings.TrimPrefix(uri, "k8s://"), "/")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid URI format. Expected %s, got: %s", uriGrammar, uri)
+		}
+	}
+
+	var cluster string
+	if len(parts) == 4 || (len(parts) == 3 && !IsResourceType(parts[0])) {
+		unescaped, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster in URI %s: %w", uri, err)
+		}
+		cluster = unescaped
+		parts = parts[1:]
+	}
+
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid URI format. Expected %s, got: %s", uriGrammar, uri)
+	}
+
+	identifier := &ResourceIdentifier{
+		Cluster: cluster,
+		Type:    K8sResourceType(parts[0]),
+		Name:    parts[len(parts)-1],
+	}
+	if !IsResourceType(parts[0]) {
+		return nil, fmt.Errorf("unknown resource type %q in URI %s", parts[0], uri)
+	}
+
+	switch {
+	case identifier.Type.ClusterScoped() && len(parts) == 3:
+		return nil, fmt.Errorf("%s is cluster-scoped and takes no namespace, expected k8s://[<cluster>/]%s/<name>, got: %s", identifier.Type, identifier.Type, uri)
+	case !identifier.Type.ClusterScoped() && len(parts) == 2:
+		return nil, fmt.Errorf("%s is namespaced, expected k8s://[<cluster>/]%s/<namespace>/<name>, got: %s", identifier.Type, identifier.Type, uri)
+	case len(parts) == 3:
+		identifier.Namespace = parts[1]
+	}
+
+	return identifier, nil
 }
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    ResourceIdentifier
+		wantErr string
+	}{
+		{name: "namespaced", uri: "k8s://pod/shop/web-0", want: ResourceIdentifier{Type: ResourceTypePod, Namespace: "shop", Name: "web-0"}},
+		{name: "namespaced in a cluster", uri: "k8s://prod/pod/shop/web-0", want: ResourceIdentifier{Cluster: "prod", Type: ResourceTypePod, Namespace: "shop", Name: "web-0"}},
+		{name: "cluster-scoped", uri: "k8s://node/node-1", want: ResourceIdentifier{Type: ResourceTypeNode, Name: "node-1"}},
+		{name: "namespace", uri: "k8s://namespace/shop", want: ResourceIdentifier{Type: ResourceTypeNamespace, Name: "shop"}},
+		{name: "escaped cluster", uri: "k8s://team%2Fa/pod/shop/web-0", want: ResourceIdentifier{Cluster: "team/a", Type: ResourceTypePod, Namespace: "shop", Name: "web-0"}},
+
+		// Three segments are <type>/<namespace>/<name> when the first is a type, and
+		// <cluster>/<type>/<name> otherwise
+		{name: "three segments starting with a type", uri: "k8s://service/node/web", want: ResourceIdentifier{Type: ResourceTypeService, Namespace: "node", Name: "web"}},
+		{name: "three segments starting with a cluster", uri: "k8s://prod/node/node-1", want: ResourceIdentifier{Cluster: "prod", Type: ResourceTypeNode, Name: "node-1"}},
+		{name: "three segments naming a cluster and a namespaced type", uri: "k8s://prod/pod/web-0", wantErr: "pod is namespaced"},
+		{name: "three segments naming no type", uri: "k8s://prod/widget/x", wantErr: `unknown resource type "widget"`},
+
+		{name: "empty namespace", uri: "k8s://pod//web-0", wantErr: "invalid URI format"},
+		{name: "empty name", uri: "k8s://pod/shop/", wantErr: "invalid URI format"},
+		{name: "empty cluster", uri: "k8s:///pod/shop/web-0", wantErr: "invalid URI format"},
+		{name: "nothing after the scheme", uri: "k8s://", wantErr: "invalid URI format"},
+
+		{name: "namespace on a cluster-scoped type", uri: "k8s://node/shop/node-1", wantErr: "node is cluster-scoped and takes no namespace"},
+		{name: "namespace on a namespace", uri: "k8s://prod/namespace/shop/web", wantErr: "namespace is cluster-scoped and takes no namespace"},
+		{name: "no namespace on a namespaced type", uri: "k8s://configmap/settings", wantErr: "configmap is namespaced"},
+
+		{name: "other scheme", uri: "https://pod/shop/web-0", wantErr: "invalid URI format"},
+		{name: "too many segments", uri: "k8s://prod/pod/shop/web-0/logs", wantErr: "invalid URI format"},
+		{name: "bad escape in cluster", uri: "k8s://prod%zz/pod/shop/web-0", wantErr: "invalid cluster"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURI(tt.uri)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseURI(%q) error = %v, want it to contain %q", tt.uri, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseURI(%q) error = %v", tt.uri, err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseURI(%q) = %+v, want %+v", tt.uri, *got, tt.want)
+			}
+			if uri := got.ToURI(); uri != tt.uri {
+				t.Errorf("ToURI() = %q, want %q", uri, tt.uri)
+			}
+		})
+	}
+}